@@ -5,9 +5,12 @@
 package plot
 
 import (
+	"bytes"
 	"fmt"
 	"image/color"
 	"math"
+	"strconv"
+	"strings"
 
 	"github.com/gonum/plot/vg"
 	"github.com/gonum/plot/vg/draw"
@@ -34,20 +37,48 @@ type Axis struct {
 	// values represented by the axis.
 	Min, Max float64
 
+	// IncludeZero specifies that the range of the axis
+	// is extended to include zero when the plot is drawn,
+	// as is usual for the value axis of a bar chart.
+	IncludeZero bool
+
 	Label struct {
 		// Text is the axis label string.
 		Text string
 
 		// TextStyle is the style of the axis label text.
 		draw.TextStyle
+
+		// MaxWidth, if positive, is the greatest width
+		// of the lines of the label.  Longer lines are
+		// wrapped at spaces, and space for the extra
+		// lines is reserved when the axis is drawn.
+		// A single word that is wider than MaxWidth
+		// is not broken.
+		MaxWidth vg.Length
 	}
 
 	// LineStyle is the style of the axis line.
+	// If its Width is not positive then the axis line
+	// is not drawn, and no space is reserved for it,
+	// but the tick marks and labels are still drawn.
 	draw.LineStyle
 
+	// DrawLine specifies whether the axis line is
+	// drawn.  If DrawLine is false then the line is
+	// omitted, and no space is reserved for it, as
+	// for a line with no width; the tick marks and
+	// labels are still drawn.  New sets DrawLine
+	// to true.
+	DrawLine bool
+
 	// Padding between the axis line and the data.  Having
 	// non-zero padding ensures that the data is never drawn
 	// on the axis, thus making it easier to see.
+	//
+	// Padding may be negative, in which case the data area
+	// extends past the axis line, but never past the edge
+	// of the canvas on which the plot is drawn.
 	Padding vg.Length
 
 	Tick struct {
@@ -58,20 +89,89 @@ type Axis struct {
 		draw.LineStyle
 
 		// Length is the length of a major tick mark.
-		// Minor tick marks are half of the length of major
-		// tick marks.
+		// Like all vg.Lengths it may be given in any
+		// unit, e.g., vg.Points(8) or vg.Inch / 9.
 		Length vg.Length
 
+		// MinorLength is the length of a minor tick mark.
+		// If MinorLength is zero then minor tick marks are
+		// half of Length, and if it is negative then they
+		// are not drawn.  Minor tick marks are drawn against
+		// the axis line, so a MinorLength greater than
+		// Length is treated as Length.
+		MinorLength vg.Length
+
 		// Marker returns the tick marks.  Any tick marks
 		// returned by the Marker function that are not in
 		// range of the axis are not drawn.
 		Marker Ticker
+
+		// LabelXAlign and LabelYAlign are the alignment
+		// of the tick labels at their positions, as the
+		// xalign and yalign arguments of draw.Canvas's
+		// FillText.  New sets them to -0.5 and 0 for the
+		// X axis, centering the labels under the ticks,
+		// and to -1 and -0.5 for the Y axis, placing the
+		// labels to the left of the ticks.
+		LabelXAlign, LabelYAlign float64
+
+		// LabelXOffset and LabelYOffset are added to
+		// the positions of the tick labels, and may be
+		// used to nudge them for fonts whose ascent and
+		// baseline place them poorly.
+		LabelXOffset, LabelYOffset vg.Length
+
+		// LabelMaxWidth, if positive, is the greatest
+		// width of the lines of the tick labels.  Longer
+		// lines are truncated, keeping their starts, and
+		// the Ellipsis is appended to them.
+		LabelMaxWidth vg.Length
+
+		// Ellipsis is the text appended to tick labels
+		// that are truncated to LabelMaxWidth.  New sets
+		// it to "…".
+		Ellipsis string
+
+		// HideMarks and HideLabels specify that the tick
+		// marks and the tick labels are not drawn, and no
+		// space is reserved for them.  Either may be hidden
+		// without the other, and the grid lines given by
+		// GridStyle are drawn at the major ticks regardless.
+		HideMarks, HideLabels bool
+
+		// AlignDecimal specifies that the tick labels of
+		// a vertical axis are aligned on their decimal
+		// points, rather than on their right edges, so
+		// that the digits of labels of different lengths
+		// line up.  Labels without a decimal point end
+		// where the point would be.  AlignDecimal is
+		// ignored by horizontal axes.
+		AlignDecimal bool
 	}
 
+	// GridStyle is the style of the grid lines drawn
+	// across the data area at the major tick marks of
+	// the axis.  It is independent of the style of the
+	// tick marks themselves.  If GridStyle is the zero
+	// value, or if its Color is nil or its Width is not
+	// positive, then no grid lines are drawn.
+	GridStyle draw.LineStyle
+
 	// Scale transforms a value given in the data coordinate system
 	// to the normalized coordinate system of the axis—its distance
 	// along the axis as a fraction of the axis range.
 	Scale Normalizer
+
+	// defaults are the lengths given to the axis by
+	// makeAxis.  Only lengths left at their defaults
+	// are scaled by Plot.Font.ScaleLengths.
+	defaults axisLengths
+}
+
+// axisLengths are the lengths of an axis
+// that are scaled with the plot font.
+type axisLengths struct {
+	padding, tick, minorTick vg.Length
 }
 
 // makeAxis returns a default Axis.
@@ -94,10 +194,11 @@ func makeAxis() (Axis, error) {
 		Max: math.Inf(-1),
 		LineStyle: draw.LineStyle{
 			Color: color.Black,
-			Width: vg.Points(0.5),
+			Width: DefaultLineWidth,
 		},
-		Padding: vg.Points(5),
-		Scale:   LinearScale{},
+		DrawLine: true,
+		Padding:  DefaultPadding,
+		Scale:    LinearScale{},
 	}
 	a.Label.TextStyle = draw.TextStyle{
 		Color: color.Black,
@@ -109,10 +210,18 @@ func makeAxis() (Axis, error) {
 	}
 	a.Tick.LineStyle = draw.LineStyle{
 		Color: color.Black,
-		Width: vg.Points(0.5),
+		Width: DefaultLineWidth,
 	}
-	a.Tick.Length = vg.Points(8)
+	a.Tick.Length = DefaultTickLength
+	a.Tick.MinorLength = DefaultMinorTickLength
 	a.Tick.Marker = DefaultTicks{}
+	a.Tick.LabelXAlign = -0.5
+	a.Tick.Ellipsis = "…"
+	a.defaults = axisLengths{
+		padding:   a.Padding,
+		tick:      a.Tick.Length,
+		minorTick: a.Tick.MinorLength,
+	}
 
 	return a, nil
 }
@@ -120,6 +229,10 @@ func makeAxis() (Axis, error) {
 // sanitizeRange ensures that the range of the
 // axis makes sense.
 func (a *Axis) sanitizeRange() {
+	if a.unset() {
+		// An axis with no data has the unit range.
+		a.Min, a.Max = 0, 1
+	}
 	if math.IsInf(a.Min, 0) {
 		a.Min = 0
 	}
@@ -129,10 +242,64 @@ func (a *Axis) sanitizeRange() {
 	if a.Min > a.Max {
 		a.Min, a.Max = a.Max, a.Min
 	}
+	if a.IncludeZero {
+		a.Min = math.Min(a.Min, 0)
+		a.Max = math.Max(a.Max, 0)
+	}
 	if a.Min == a.Max {
 		a.Min -= 1
 		a.Max += 1
 	}
+	// Ranges are rounded to ticks spaced
+	// linearly, so only linear axes are rounded.
+	if _, ok := a.Scale.(LinearScale); !ok {
+		return
+	}
+	if r, ok := a.Tick.Marker.(rangeRounder); ok {
+		a.Min, a.Max = r.roundRange(a.Min, a.Max)
+	}
+}
+
+// unset returns true if neither end of the range of the
+// axis has been set, as for a plot with no data.
+func (a *Axis) unset() bool {
+	return math.IsInf(a.Min, 0) && math.IsInf(a.Max, 0)
+}
+
+// rangeWarnings returns descriptions of the changes
+// that sanitizeRange will make to the range of the
+// axis.  The name is used to identify the axis.
+func (a *Axis) rangeWarnings(name string) []string {
+	var warns []string
+	if a.unset() {
+		warns = append(warns, fmt.Sprintf("%s axis range is not set: using 0 to 1", name))
+	} else if math.IsInf(a.Min, 0) || math.IsInf(a.Max, 0) {
+		warns = append(warns, fmt.Sprintf("%s axis range is not set: using a default", name))
+	} else if a.Min > a.Max {
+		warns = append(warns, fmt.Sprintf("%s axis range is inverted: swapped Min=%g and Max=%g", name, a.Min, a.Max))
+	} else if a.Min == a.Max && !a.IncludeZero || a.Min == 0 && a.Max == 0 {
+		warns = append(warns, fmt.Sprintf("%s axis range is empty: expanded about %g", name, a.Min))
+	}
+	return warns
+}
+
+// validate returns an error if the range of the axis
+// contains a NaN, has only one end set, or is inverted.
+// A range with neither end set, as for a plot with no
+// data, is valid.  The name is used to identify the
+// axis in the error.
+func (a *Axis) validate(name string) error {
+	switch {
+	case math.IsNaN(a.Min) || math.IsNaN(a.Max):
+		return fmt.Errorf("plot: %s axis range contains NaN: Min=%g, Max=%g", name, a.Min, a.Max)
+	case a.unset():
+		return nil
+	case math.IsInf(a.Min, 0) || math.IsInf(a.Max, 0):
+		return fmt.Errorf("plot: %s axis range is only partly set: Min=%g, Max=%g", name, a.Min, a.Max)
+	case a.Min > a.Max:
+		return fmt.Errorf("plot: %s axis range is inverted: Min=%g > Max=%g", name, a.Min, a.Max)
+	}
+	return nil
 }
 
 // LinearScale an be used as the value of an Axis.Scale function to
@@ -156,6 +323,120 @@ func (LogScale) Normalize(min, max, x float64) float64 {
 	return (log(x) - logMin) / (log(max) - logMin)
 }
 
+// BrokenScale can be used as the value of an Axis.Scale function
+// to set the axis to a linear scale with breaks, ranges of values
+// that are cut out of the axis to compress large empty ranges of
+// the data.  Each break is shown as a short gap in the axis, in
+// which a zigzag break symbol is drawn across the axis line.
+//
+// A BrokenScale is also a Ticker that marks each of the pieces
+// of the axis between the breaks separately, so it is typically
+// used as the value of both Axis.Scale and Axis.Tick.Marker.
+type BrokenScale struct {
+	// Breaks are the ranges of values removed from the
+	// axis, in increasing order and not overlapping.
+	// Breaks that are not entirely within the range of
+	// the axis are ignored.
+	Breaks []AxisBreak
+
+	// Gap is the length of the axis given to each break
+	// as a fraction of the length of the axis.
+	Gap float64
+
+	// Ticker returns the ticks of each piece of the axis.
+	// If Ticker is nil then DefaultTicks is used.
+	Ticker Ticker
+}
+
+// AxisBreak is a range of values that is removed
+// from an axis with a BrokenScale.
+type AxisBreak struct {
+	Min, Max float64
+}
+
+var _ Normalizer = BrokenScale{}
+
+// NewBrokenScale returns a BrokenScale with the given breaks,
+// each given a gap of 0.02 of the length of the axis.
+func NewBrokenScale(breaks ...AxisBreak) BrokenScale {
+	return BrokenScale{Breaks: breaks, Gap: 0.02}
+}
+
+// within returns the breaks that lie entirely
+// within the range from min to max.
+func (s BrokenScale) within(min, max float64) []AxisBreak {
+	var bs []AxisBreak
+	for _, b := range s.Breaks {
+		if b.Min < b.Max && b.Min > min && b.Max < max {
+			bs = append(bs, b)
+		}
+	}
+	return bs
+}
+
+// Normalize implements the Normalizer interface.  Values
+// within a break are mapped linearly across its gap.
+func (s BrokenScale) Normalize(min, max, x float64) float64 {
+	bs := s.within(min, max)
+	kept := max - min
+	for _, b := range bs {
+		kept -= b.Max - b.Min
+	}
+	span := 1 - float64(len(bs))*s.Gap
+	if kept <= 0 || span <= 0 {
+		return LinearScale{}.Normalize(min, max, x)
+	}
+
+	var pos float64
+	prev := min
+	for _, b := range bs {
+		if x < b.Min {
+			break
+		}
+		start := pos + (b.Min-prev)/kept*span
+		if x <= b.Max {
+			return start + (x-b.Min)/(b.Max-b.Min)*s.Gap
+		}
+		pos = start + s.Gap
+		prev = b.Max
+	}
+	return pos + (x-prev)/kept*span
+}
+
+// Ticks implements the Ticker interface, returning the
+// ticks of each of the pieces of the axis between the
+// breaks.
+func (s BrokenScale) Ticks(min, max float64) []Tick {
+	tkr := s.Ticker
+	if tkr == nil {
+		tkr = DefaultTicks{}
+	}
+	var ticks []Tick
+	lo := min
+	for _, b := range s.within(min, max) {
+		ticks = append(ticks, tkr.Ticks(lo, b.Min)...)
+		lo = b.Max
+	}
+	return append(ticks, tkr.Ticks(lo, max)...)
+}
+
+// gaps returns the normalized ranges of the axis
+// from min to max that are given to its breaks.  It
+// implements the breaker interface.
+func (s BrokenScale) gaps(min, max float64) [][2]float64 {
+	var gs [][2]float64
+	for _, b := range s.within(min, max) {
+		gs = append(gs, [2]float64{s.Normalize(min, max, b.Min), s.Normalize(min, max, b.Max)})
+	}
+	return gs
+}
+
+// breaker is implemented by Normalizers with breaks
+// that are drawn as gaps in the axis line.
+type breaker interface {
+	gaps(min, max float64) [][2]float64
+}
+
 // Norm returns the value of x, given in the data coordinate
 // system, normalized to its distance as a fraction of the
 // range of this axis.  For example, if x is a.Min then the return
@@ -164,84 +445,477 @@ func (a *Axis) Norm(x float64) float64 {
 	return a.Scale.Normalize(a.Min, a.Max, x)
 }
 
+// norms returns the values of xs normalized as by Norm and
+// then mapped linearly from the unit range to min to max.
+// The scaling is computed once for all of the values.
+func (a *Axis) norms(xs []float64, min, max vg.Length) []vg.Length {
+	ls := make([]vg.Length, len(xs))
+	switch a.Scale.(type) {
+	case LinearScale, *LinearScale:
+		s := float64(max-min) / (a.Max - a.Min)
+		for i, x := range xs {
+			ls[i] = vg.Length((x-a.Min)*s) + min
+		}
+	case LogScale, *LogScale:
+		logMin := log(a.Min)
+		s := float64(max-min) / (log(a.Max) - logMin)
+		for i, x := range xs {
+			ls[i] = vg.Length((log(x)-logMin)*s) + min
+		}
+	default:
+		for i, x := range xs {
+			ls[i] = vg.Length(a.Norm(x))*(max-min) + min
+		}
+	}
+	return ls
+}
+
+// Zoom scales the range of the axis by 1/factor around
+// center, given in the data coordinate system, so that a factor
+// greater than 1 zooms in and a factor less than 1 zooms out.
+// The value at center remains at the same position along
+// the axis.  If the axis uses LogScale then the range is
+// scaled multiplicatively, keeping its extent in decades
+// proportional.
+//
+// The factor must be positive, and for a LogScale axis
+// the center must be positive too.  Otherwise the range
+// is left unchanged.
+func (a *Axis) Zoom(factor, center float64) {
+	if !(factor > 0) || a.isLog() && !(center > 0) {
+		return
+	}
+	if a.isLog() {
+		a.Min = center * math.Pow(a.Min/center, 1/factor)
+		a.Max = center * math.Pow(a.Max/center, 1/factor)
+		return
+	}
+	a.Min = center - (center-a.Min)/factor
+	a.Max = center + (a.Max-center)/factor
+}
+
+// Pan shifts the range of the axis by delta, given in the
+// data coordinate system.  If the axis uses LogScale then
+// delta is in decades, i.e. Min and Max are multiplied
+// by 10^delta.
+func (a *Axis) Pan(delta float64) {
+	if a.isLog() {
+		f := math.Pow(10, delta)
+		a.Min *= f
+		a.Max *= f
+		return
+	}
+	a.Min += delta
+	a.Max += delta
+}
+
+// TickMarks returns the ticks that are drawn along the axis
+// for its current range, as given by its Tick.Marker.  The
+// range is first adjusted as it is when the axis is drawn,
+// without changing the axis, so TickMarks may be used to
+// draw the ticks with other graphics libraries.
+func (a *Axis) TickMarks() []Tick {
+	b := *a
+	b.sanitizeRange()
+	return b.ticks()
+}
+
+// ticks returns the ticks given by the Marker for the
+// range of the axis, with their labels truncated to the
+// LabelMaxWidth, or removed if the labels are hidden.
+func (a *Axis) ticks() []Tick {
+	ticks := a.Tick.Marker.Ticks(a.Min, a.Max)
+	if a.Tick.LabelMaxWidth <= 0 && !a.Tick.HideLabels {
+		return ticks
+	}
+	// The ticks are copied, as a Marker such as
+	// ConstantTicks may return its own slice.
+	labelled := make([]Tick, len(ticks))
+	for i, t := range ticks {
+		if a.Tick.HideLabels {
+			// Major ticks keep their kind without
+			// their labels, for their grid lines
+			// and the lengths of their marks.
+			if t.Kind == AutoTick && !t.IsMinor() {
+				t.Kind = MajorTick
+			}
+			t.Label = ""
+		} else {
+			t.Label = truncateText(a.Tick.Label, t.Label, a.Tick.Ellipsis, a.Tick.LabelMaxWidth)
+		}
+		labelled[i] = t
+	}
+	return labelled
+}
+
+// extent returns the length of the range of the axis,
+// in decades if the axis uses LogScale.
+func (a *Axis) extent() float64 {
+	if a.isLog() {
+		return math.Log10(a.Max) - math.Log10(a.Min)
+	}
+	return a.Max - a.Min
+}
+
+// isLog returns true if the axis uses LogScale.
+func (a *Axis) isLog() bool {
+	switch a.Scale.(type) {
+	case LogScale, *LogScale:
+		return true
+	}
+	return false
+}
+
+// labelText returns the text of the axis label,
+// wrapped to the label's MaxWidth.
+func (a *Axis) labelText() string {
+	if a.Label.MaxWidth <= 0 {
+		return a.Label.Text
+	}
+	return wrapText(a.Label.TextStyle, a.Label.Text, a.Label.MaxWidth)
+}
+
+// minLength returns the least length of the axis at which
+// none of its major tick labels overlap, where size gives
+// the extent of a label along the axis and gap is the
+// space required between adjacent labels.  It also returns
+// the distance that the labels then extend past the
+// maximum end of the axis.
+func (a *Axis) minLength(size func(string) vg.Length, gap vg.Length) (length, over vg.Length) {
+	type label struct {
+		pos  float64
+		size vg.Length
+	}
+	var ls []label
+	for _, t := range a.ticks() {
+		f := a.Norm(t.Value)
+		if !t.HasLabel() || f < 0 || f > 1 {
+			continue
+		}
+		ls = append(ls, label{pos: f, size: size(t.Label)})
+	}
+	for _, l := range ls {
+		next := -1
+		for j, m := range ls {
+			if m.pos > l.pos && (next < 0 || m.pos < ls[next].pos) {
+				next = j
+			}
+		}
+		if next < 0 {
+			continue
+		}
+		need := (l.size+ls[next].size)/2 + gap
+		if n := need / vg.Length(ls[next].pos-l.pos); n > length {
+			length = n
+		}
+	}
+	for _, l := range ls {
+		if o := l.size/2 - vg.Length(1-l.pos)*length; o > over {
+			over = o
+		}
+	}
+	return length, over
+}
+
+// exponentText returns the text giving the power
+// of ten shared by the tick labels of the axis, or
+// the empty string if the labels have no exponent.
+func (a *Axis) exponentText() string {
+	e, ok := a.Tick.Marker.(exponenter)
+	if !ok || a.Tick.HideLabels {
+		return ""
+	}
+	n := e.Exponent(a.Min, a.Max)
+	if n == 0 {
+		return ""
+	}
+	return fmt.Sprintf("×10^%d", n)
+}
+
+// hasGrid returns true if the grid lines should be drawn.
+func (a *Axis) hasGrid() bool {
+	return a.GridStyle.Color != nil && a.GridStyle.Width > 0
+}
+
+// drawLine returns true if the axis line should be drawn.
+func (a *Axis) drawLine() bool {
+	return a.DrawLine && a.Width > 0
+}
+
 // drawTicks returns true if the tick marks should be drawn.
 func (a *Axis) drawTicks() bool {
-	return a.Tick.Width > 0 && a.Tick.Length > 0
+	return !a.Tick.HideMarks && a.Tick.Width > 0 && a.Tick.Length > 0
+}
+
+// lines returns the lines that draw the axis line from p0,
+// at the minimum of the axis, to p1.  If the Scale has
+// breaks then the line is cut at each of their gaps, and a
+// zigzag break symbol the height of a major tick mark is
+// drawn across each gap.
+func (a *Axis) lines(p0, p1 draw.Point) [][]draw.Point {
+	b, ok := a.Scale.(breaker)
+	if !ok {
+		return [][]draw.Point{{p0, p1}}
+	}
+	at := func(f float64, off vg.Length) draw.Point {
+		// The offset is perpendicular to the axis, and the
+		// axes are either horizontal or vertical.
+		p := draw.Point{
+			X: p0.X + (p1.X-p0.X)*vg.Length(f),
+			Y: p0.Y + (p1.Y-p0.Y)*vg.Length(f),
+		}
+		if p0.X == p1.X {
+			p.X += off
+		} else {
+			p.Y += off
+		}
+		return p
+	}
+	amp := a.Tick.Length / 2
+	var lines [][]draw.Point
+	start := 0.0
+	for _, g := range b.gaps(a.Min, a.Max) {
+		lines = append(lines, []draw.Point{at(start, 0), at(g[0], 0)})
+		w := g[1] - g[0]
+		lines = append(lines, []draw.Point{
+			at(g[0], 0),
+			at(g[0]+w/4, amp),
+			at(g[0]+3*w/4, -amp),
+			at(g[1], 0),
+		})
+		start = g[1]
+	}
+	return append(lines, []draw.Point{at(start, 0), p1})
+}
+
+// labelShiftX and labelShiftY return the distance from
+// the center of a tick label of the given width or height
+// to the position of its tick along the horizontal and the
+// vertical, given the label alignment and offset.
+func (a *Axis) labelShiftX(w vg.Length) vg.Length {
+	return w*vg.Length(a.Tick.LabelXAlign+0.5) + a.Tick.LabelXOffset
+}
+
+func (a *Axis) labelShiftY(h vg.Length) vg.Length {
+	return h*vg.Length(a.Tick.LabelYAlign+0.5) + a.Tick.LabelYOffset
 }
 
 // A horizontalAxis draws horizontally across the bottom
-// of a plot.
+// of a plot, or across the top if top is true.
 type horizontalAxis struct {
 	Axis
+
+	// top specifies that the axis is drawn along
+	// the top of the plot, mirroring the usual axis
+	// so that its labels are on the outside.
+	top bool
+
+	// join is the distance that the axis line is
+	// extended past the minimum of the axis so that
+	// it meets the line of the vertical axis.
+	join vg.Length
+
+	// bounds is the area within which the tick
+	// labels are drawn.  Labels at the ends of the
+	// axis that would extend past it are shifted
+	// inward.  An empty bounds is ignored.
+	bounds draw.Rectangle
 }
 
 // size returns the height of the axis.
 func (a *horizontalAxis) size() (h vg.Length) {
-	if a.Label.Text != "" {
+	if txt := a.labelText(); txt != "" {
 		h -= a.Label.Font.Extents().Descent
-		h += a.Label.Height(a.Label.Text)
+		h += a.Label.Height(txt)
+	}
+	if exp := a.exponentText(); exp != "" {
+		h -= a.Tick.Label.Font.Extents().Descent
+		h += a.Tick.Label.Height(exp)
 	}
-	if marks := a.Tick.Marker.Ticks(a.Min, a.Max); len(marks) > 0 {
+	if marks := a.ticks(); len(marks) > 0 {
 		if a.drawTicks() {
 			h += a.Tick.Length
 		}
 		h += tickLabelHeight(a.Tick.Label, marks)
+		if a.top {
+			h -= a.Tick.Label.Font.Extents().Descent
+		}
+	}
+	if a.drawLine() {
+		h += a.Width / 2
 	}
-	h += a.Width / 2
 	h += a.Padding
+	if h < 0 {
+		h = 0
+	}
 	return
 }
 
-// draw draws the axis along the lower edge of a draw.Canvas.
-func (a *horizontalAxis) draw(c draw.Canvas) {
-	y := c.Min.Y
-	if a.Label.Text != "" {
+// draw draws the axis along the lower edge of a draw.Canvas,
+// or along its upper edge if the axis is a top axis, apart
+// from the axis line.  It returns the Y location of the axis
+// line, which is drawn by strokeLine.
+func (a *horizontalAxis) draw(c draw.Canvas) (y vg.Length) {
+	if a.top {
+		return a.drawTop(c)
+	}
+	y = c.Min.Y
+	if txt := a.labelText(); txt != "" {
 		y -= a.Label.Font.Extents().Descent
-		c.FillText(a.Label.TextStyle, c.Center().X, y, -0.5, 0, a.Label.Text)
-		y += a.Label.Height(a.Label.Text)
+		c.FillText(a.Label.TextStyle, c.Center().X, y, -0.5, 0, txt)
+		y += a.Label.Height(txt)
+	}
+	if exp := a.exponentText(); exp != "" {
+		// The exponent has its own row at the end of the
+		// axis so that it never overlaps the centered label.
+		y -= a.Tick.Label.Font.Extents().Descent
+		c.FillText(a.Tick.Label, c.Max.X, y, -1, 0, exp)
+		y += a.Tick.Label.Height(exp)
 	}
 
-	marks := a.Tick.Marker.Ticks(a.Min, a.Max)
+	marks := a.ticks()
 	for _, t := range marks {
 		x := c.X(a.Norm(t.Value))
-		if !c.ContainsX(x) || t.IsMinor() {
+		if !c.ContainsX(x) || !t.HasLabel() {
 			continue
 		}
-		c.FillText(a.Tick.Label, x, y, -0.5, 0, t.Label)
+		w := a.Tick.Label.Width(t.Label)
+		x = shiftInside(x+a.labelShiftX(w), w, a.bounds.Min.X, a.bounds.Max.X) - a.labelShiftX(w)
+		c.FillText(a.Tick.Label, x+a.Tick.LabelXOffset, y+a.Tick.LabelYOffset, a.Tick.LabelXAlign, a.Tick.LabelYAlign, t.Label)
 	}
 
 	if len(marks) > 0 {
 		y += tickLabelHeight(a.Tick.Label, marks)
-	} else {
+	} else if a.drawLine() {
 		y += a.Width / 2
 	}
 
 	if len(marks) > 0 && a.drawTicks() {
 		len := a.Tick.Length
+		var lines [][]draw.Point
 		for _, t := range marks {
 			x := c.X(a.Norm(t.Value))
 			if !c.ContainsX(x) {
 				continue
 			}
-			start := t.lengthOffset(len)
-			c.StrokeLine2(a.Tick.LineStyle, x, y+start, x, y+len)
+			start := t.lengthOffset(len, a.Tick.MinorLength)
+			lines = append(lines, []draw.Point{{x, y + start}, {x, y + len}})
 		}
+		c.StrokeLines(a.Tick.LineStyle, lines...)
 		y += len
 	}
+	return y
+}
+
+// drawTop draws the axis along the upper edge of a
+// draw.Canvas, as draw does along the lower edge but
+// with the label outermost and the axis line innermost.
+func (a *horizontalAxis) drawTop(c draw.Canvas) (y vg.Length) {
+	y = c.Max.Y
+	if txt := a.labelText(); txt != "" {
+		c.FillText(a.Label.TextStyle, c.Center().X, y, -0.5, -1, txt)
+		y -= a.Label.Height(txt) - a.Label.Font.Extents().Descent
+	}
+	if exp := a.exponentText(); exp != "" {
+		c.FillText(a.Tick.Label, c.Max.X, y, -1, -1, exp)
+		y -= a.Tick.Label.Height(exp) - a.Tick.Label.Font.Extents().Descent
+	}
+
+	marks := a.ticks()
+	if len(marks) > 0 {
+		// Labels of different heights are aligned
+		// along their bottoms, nearest the axis.
+		y -= tickLabelHeight(a.Tick.Label, marks)
+		for _, t := range marks {
+			x := c.X(a.Norm(t.Value))
+			if !c.ContainsX(x) || !t.HasLabel() {
+				continue
+			}
+			w := a.Tick.Label.Width(t.Label)
+			x = shiftInside(x+a.labelShiftX(w), w, a.bounds.Min.X, a.bounds.Max.X) - a.labelShiftX(w)
+			c.FillText(a.Tick.Label, x+a.Tick.LabelXOffset, y+a.Tick.LabelYOffset, a.Tick.LabelXAlign, a.Tick.LabelYAlign, t.Label)
+		}
+		y += a.Tick.Label.Font.Extents().Descent
+	} else if a.drawLine() {
+		y -= a.Width / 2
+	}
+
+	if len(marks) > 0 && a.drawTicks() {
+		len := a.Tick.Length
+		var lines [][]draw.Point
+		for _, t := range marks {
+			x := c.X(a.Norm(t.Value))
+			if !c.ContainsX(x) {
+				continue
+			}
+			start := t.lengthOffset(len, a.Tick.MinorLength)
+			lines = append(lines, []draw.Point{{x, y - start}, {x, y - len}})
+		}
+		c.StrokeLines(a.Tick.LineStyle, lines...)
+		y -= len
+	}
+	return y
+}
 
-	c.StrokeLine2(a.LineStyle, c.Min.X, y, c.Max.X, y)
+// strokeLine draws the axis line across a draw.Canvas
+// at the Y location y returned by draw.
+func (a *horizontalAxis) strokeLine(c draw.Canvas, y vg.Length) {
+	if !a.drawLine() {
+		return
+	}
+	// Any breaks are placed along the axis
+	// without the piece that joins the Y axis.
+	p0 := draw.Point{c.Min.X, y}
+	lines := a.lines(p0, draw.Point{c.Max.X, y})
+	if a.join != 0 {
+		lines = append([][]draw.Point{{{p0.X - a.join, y}, p0}}, lines...)
+	}
+	c.StrokeLines(a.LineStyle, lines...)
+}
+
+// labelsOverlap returns whether any of the tick
+// labels drawn on the axis overlap.
+func (a *horizontalAxis) labelsOverlap(c draw.Canvas) bool {
+	var lo, hi []vg.Length
+	for _, t := range a.ticks() {
+		x := c.X(a.Norm(t.Value))
+		if !c.ContainsX(x) || !t.HasLabel() {
+			continue
+		}
+		w := a.Tick.Label.Width(t.Label)
+		x += a.labelShiftX(w)
+		lo = append(lo, x-w/2)
+		hi = append(hi, x+w/2)
+	}
+	return anyOverlap(lo, hi)
+}
+
+// gridLines returns the vertical grid lines across
+// the draw.Canvas at the major tick marks.
+func (a *horizontalAxis) gridLines(c draw.Canvas) (lines [][]draw.Point) {
+	if !a.hasGrid() {
+		return nil
+	}
+	for _, t := range a.ticks() {
+		x := c.X(a.Norm(t.Value))
+		if !c.ContainsX(x) || t.IsMinor() {
+			continue
+		}
+		lines = append(lines, []draw.Point{{x, c.Min.Y}, {x, c.Max.Y}})
+	}
+	return lines
 }
 
 // GlyphBoxes returns the GlyphBoxes for the tick labels.
 func (a *horizontalAxis) GlyphBoxes(*Plot) (boxes []GlyphBox) {
-	for _, t := range a.Tick.Marker.Ticks(a.Min, a.Max) {
-		if t.IsMinor() {
+	for _, t := range a.ticks() {
+		if !t.HasLabel() {
 			continue
 		}
 		w := a.Tick.Label.Width(t.Label)
 		box := GlyphBox{
 			X:         a.Norm(t.Value),
-			Rectangle: draw.Rectangle{draw.Point{X: -w / 2}, draw.Point{X: w / 2}},
+			Rectangle: draw.Rectangle{draw.Point{X: a.labelShiftX(w) - w/2}, draw.Point{X: a.labelShiftX(w) + w/2}},
 		}
 		boxes = append(boxes, box)
 	}
@@ -251,16 +925,35 @@ func (a *horizontalAxis) GlyphBoxes(*Plot) (boxes []GlyphBox) {
 // A verticalAxis is drawn vertically up the left side of a plot.
 type verticalAxis struct {
 	Axis
+
+	// hideOrigin specifies that the tick
+	// label at zero is not drawn.
+	hideOrigin bool
+
+	// join is the distance that the axis line is
+	// extended past the minimum of the axis so that
+	// it meets the line of the horizontal axis.
+	join vg.Length
+
+	// bounds is the area within which the tick
+	// labels are drawn.  Labels at the ends of the
+	// axis that would extend past it are shifted
+	// inward.  An empty bounds is ignored.
+	bounds draw.Rectangle
 }
 
 // size returns the width of the axis.
 func (a *verticalAxis) size() (w vg.Length) {
-	if a.Label.Text != "" {
+	if txt := a.labelText(); txt != "" {
 		w -= a.Label.Font.Extents().Descent
-		w += a.Label.Height(a.Label.Text)
+		w += a.Label.Height(txt)
+	}
+	if exp := a.exponentText(); exp != "" {
+		w -= a.Tick.Label.Font.Extents().Descent
+		w += a.Tick.Label.Height(exp)
 	}
-	if marks := a.Tick.Marker.Ticks(a.Min, a.Max); len(marks) > 0 {
-		if lwidth := tickLabelWidth(a.Tick.Label, marks); lwidth > 0 {
+	if marks := a.ticks(); len(marks) > 0 {
+		if lwidth, _ := a.labelWidth(marks); lwidth > 0 {
 			w += lwidth
 			w += a.Label.Width(" ")
 		}
@@ -268,33 +961,53 @@ func (a *verticalAxis) size() (w vg.Length) {
 			w += a.Tick.Length
 		}
 	}
-	w += a.Width / 2
+	if a.drawLine() {
+		w += a.Width / 2
+	}
 	w += a.Padding
+	if w < 0 {
+		w = 0
+	}
 	return
 }
 
-// draw draws the axis along the left side of a draw.Canvas.
-func (a *verticalAxis) draw(c draw.Canvas) {
-	x := c.Min.X
-	if a.Label.Text != "" {
-		x += a.Label.Height(a.Label.Text)
-		c.Push()
-		c.Rotate(math.Pi / 2)
-		c.FillText(a.Label.TextStyle, c.Center().Y, -x, -0.5, 0, a.Label.Text)
-		c.Pop()
+// draw draws the axis along the left side of a draw.Canvas,
+// apart from the axis line.  It returns the X location of
+// the axis line, which is drawn by strokeLine.
+func (a *verticalAxis) draw(c draw.Canvas) (x vg.Length) {
+	x = c.Min.X
+	if txt := a.labelText(); txt != "" {
+		x += a.Label.Height(txt)
+		c.FillTextRotated(a.Label.TextStyle, x, c.Center().Y, -0.5, 0, math.Pi/2, txt)
 		x += -a.Label.Font.Extents().Descent
 	}
-	marks := a.Tick.Marker.Ticks(a.Min, a.Max)
-	if w := tickLabelWidth(a.Tick.Label, marks); len(marks) > 0 && w > 0 {
+	if exp := a.exponentText(); exp != "" {
+		x += a.Tick.Label.Height(exp)
+		c.FillTextRotated(a.Tick.Label, x, c.Max.Y, -1, 0, math.Pi/2, exp)
+		x += -a.Tick.Label.Font.Extents().Descent
+	}
+	marks := a.ticks()
+	w, frac := a.labelWidth(marks)
+	if len(marks) > 0 && w > 0 {
 		x += w
 	}
 	major := false
 	for _, t := range marks {
 		y := c.Y(a.Norm(t.Value))
-		if !c.ContainsY(y) || t.IsMinor() {
+		if !c.ContainsY(y) || !t.HasLabel() {
 			continue
 		}
-		c.FillText(a.Tick.Label, x, y, -1, -0.5, t.Label)
+		if a.hideOrigin && t.Value == 0 {
+			major = true
+			continue
+		}
+		h := a.Tick.Label.Height(t.Label)
+		y = shiftInside(y+a.labelShiftY(h), h, a.bounds.Min.Y, a.bounds.Max.Y) - a.labelShiftY(h)
+		lx := x
+		if a.Tick.AlignDecimal {
+			lx -= frac - fracWidth(a.Tick.Label, t.Label)
+		}
+		c.FillText(a.Tick.Label, lx+a.Tick.LabelXOffset, y+a.Tick.LabelYOffset, a.Tick.LabelXAlign, a.Tick.LabelYAlign, t.Label)
 		major = true
 	}
 	if major {
@@ -302,29 +1015,111 @@ func (a *verticalAxis) draw(c draw.Canvas) {
 	}
 	if a.drawTicks() && len(marks) > 0 {
 		len := a.Tick.Length
+		var lines [][]draw.Point
 		for _, t := range marks {
 			y := c.Y(a.Norm(t.Value))
 			if !c.ContainsY(y) {
 				continue
 			}
-			start := t.lengthOffset(len)
-			c.StrokeLine2(a.Tick.LineStyle, x+start, y, x+len, y)
+			start := t.lengthOffset(len, a.Tick.MinorLength)
+			lines = append(lines, []draw.Point{{x + start, y}, {x + len, y}})
 		}
+		c.StrokeLines(a.Tick.LineStyle, lines...)
 		x += len
 	}
-	c.StrokeLine2(a.LineStyle, x, c.Min.Y, x, c.Max.Y)
+	return x
+}
+
+// strokeLine draws the axis line up a draw.Canvas
+// at the X location x returned by draw.
+func (a *verticalAxis) strokeLine(c draw.Canvas, x vg.Length) {
+	if !a.drawLine() {
+		return
+	}
+	p0 := draw.Point{x, c.Min.Y}
+	lines := a.lines(p0, draw.Point{x, c.Max.Y})
+	if a.join != 0 {
+		lines = append([][]draw.Point{{{x, p0.Y - a.join}, p0}}, lines...)
+	}
+	c.StrokeLines(a.LineStyle, lines...)
+}
+
+// labelWidth returns the width of the tick labels of the
+// axis and, if they are aligned on their decimal points,
+// the greatest width of the parts of the labels from their
+// decimal points.
+func (a *verticalAxis) labelWidth(ticks []Tick) (w, frac vg.Length) {
+	if !a.Tick.AlignDecimal {
+		return tickLabelWidth(a.Tick.Label, ticks), 0
+	}
+	var whole vg.Length
+	for _, t := range ticks {
+		if !t.HasLabel() {
+			continue
+		}
+		f := fracWidth(a.Tick.Label, t.Label)
+		if f > frac {
+			frac = f
+		}
+		if lw := a.Tick.Label.Width(t.Label) - f; lw > whole {
+			whole = lw
+		}
+	}
+	return whole + frac, frac
+}
+
+// fracWidth returns the width of the part of the label
+// from its decimal point, or zero if it has none.
+func fracWidth(sty draw.TextStyle, label string) vg.Length {
+	if i := strings.LastIndex(label, "."); i >= 0 {
+		return sty.Width(label[i:])
+	}
+	return 0
+}
+
+// labelsOverlap returns whether any of the tick
+// labels drawn on the axis overlap.
+func (a *verticalAxis) labelsOverlap(c draw.Canvas) bool {
+	var lo, hi []vg.Length
+	for _, t := range a.ticks() {
+		y := c.Y(a.Norm(t.Value))
+		if !c.ContainsY(y) || !t.HasLabel() || a.hideOrigin && t.Value == 0 {
+			continue
+		}
+		h := a.Tick.Label.Height(t.Label)
+		y += a.labelShiftY(h)
+		lo = append(lo, y-h/2)
+		hi = append(hi, y+h/2)
+	}
+	return anyOverlap(lo, hi)
+}
+
+// gridLines returns the horizontal grid lines across
+// the draw.Canvas at the major tick marks.
+func (a *verticalAxis) gridLines(c draw.Canvas) (lines [][]draw.Point) {
+	if !a.hasGrid() {
+		return nil
+	}
+	for _, t := range a.ticks() {
+		y := c.Y(a.Norm(t.Value))
+		if !c.ContainsY(y) || t.IsMinor() {
+			continue
+		}
+		lines = append(lines, []draw.Point{{c.Min.X, y}, {c.Max.X, y}})
+	}
+	return lines
 }
 
 // GlyphBoxes returns the GlyphBoxes for the tick labels
 func (a *verticalAxis) GlyphBoxes(*Plot) (boxes []GlyphBox) {
-	for _, t := range a.Tick.Marker.Ticks(a.Min, a.Max) {
-		if t.IsMinor() {
+	for _, t := range a.ticks() {
+		if !t.HasLabel() {
 			continue
 		}
 		h := a.Tick.Label.Height(t.Label)
 		box := GlyphBox{
 			Y:         a.Norm(t.Value),
-			Rectangle: draw.Rectangle{draw.Point{Y: -h / 2}, draw.Point{Y: h / 2}},
+			Rectangle: draw.Rectangle{draw.Point{Y: a.labelShiftY(h) - h/2}, draw.Point{Y: a.labelShiftY(h) + h/2}},
 		}
 		boxes = append(boxes, box)
 	}
@@ -333,35 +1128,195 @@ func (a *verticalAxis) GlyphBoxes(*Plot) (boxes []GlyphBox) {
 
 // DefaultTicks is suitable for the Tick.Marker field of an Axis,
 // it returns a resonable default set of tick marks.
-type DefaultTicks struct{}
+type DefaultTicks struct {
+	// IncludeEnds specifies whether labeled ticks are
+	// added at exactly the minimum and maximum of the
+	// range.  An end tick is not added if there is already
+	// a major tick very close to it, and any minor ticks
+	// very close to it are removed.  By default only the
+	// round-number ticks are returned.
+	IncludeEnds bool
+
+	// Rounding specifies whether the ticks are kept
+	// within the range or the range is rounded outward
+	// to the nearest major ticks.  The default is
+	// RoundInward.
+	Rounding TickRounding
+
+	// Minors is the number of evenly spaced minor ticks
+	// between each pair of adjacent major ticks, such as
+	// 4 for the lines of graph paper.  If Minors is zero
+	// then the number depends on the spacing of the major
+	// ticks, as for NiceTicks, and if Minors is negative
+	// then there are no minor ticks.
+	Minors int
+
+	// ExpThreshold, if positive, specifies that the labels
+	// of the major ticks are all in the same notation:
+	// exponential if the largest magnitude of the range is
+	// at least ExpThreshold or less than its reciprocal, and
+	// fixed otherwise.  If ExpThreshold is zero then each
+	// label is formatted on its own with %g, which may mix
+	// notations on one axis, such as "500000" and "1e+06".
+	ExpThreshold float64
+}
+
+// TickRounding specifies how the ticks returned by
+// DefaultTicks are placed relative to the range.
+type TickRounding int
+
+const (
+	// RoundInward keeps all of the ticks within the
+	// range, so the ends of the range are generally
+	// not at major ticks.
+	RoundInward TickRounding = iota
+
+	// RoundOutward rounds the range outward to the
+	// nearest major ticks, so ticks may lie beyond the
+	// data.  A positive minimum is never rounded to zero
+	// or below; it is left unrounded instead.  When used
+	// for the Tick.Marker of an Axis with a LinearScale,
+	// the range of the axis is also rounded outward so
+	// that the axis begins and ends at major ticks,
+	// even if its Min and Max were set explicitly.
+	RoundOutward
+)
 
 var _ Ticker = DefaultTicks{}
 
+// suggestedTicks is the suggested number of
+// major ticks returned by DefaultTicks.
+const suggestedTicks = 3
+
 // Ticks returns Ticks in a specified range
-func (DefaultTicks) Ticks(min, max float64) (ticks []Tick) {
-	const SuggestedTicks = 3
+func (dt DefaultTicks) Ticks(min, max float64) []Tick {
+	if max < min {
+		panic("illegal range")
+	}
+	min, max = dt.roundRange(min, max)
+	ticks, majorDelta := niceTicks(min, max, suggestedTicks, dt.ExpThreshold)
+	if dt.Minors != 0 && majorDelta > 0 {
+		majors := ticks[:0]
+		for _, t := range ticks {
+			if !t.IsMinor() {
+				majors = append(majors, t)
+			}
+		}
+		ticks = majors
+		if dt.Minors > 0 {
+			ticks = addMinors(ticks, min, max, majorDelta/float64(dt.Minors+1))
+		}
+	}
+	if dt.IncludeEnds {
+		label := formatTick
+		if dt.ExpThreshold > 0 && majorDelta > 0 {
+			label = tickLabeller(min, max, majorDelta, dt.ExpThreshold)
+		}
+		ticks = includeEnds(ticks, min, max, majorDelta/10, label)
+	}
+	return ticks
+}
+
+// rangeRounder is implemented by Tickers
+// that may extend the range of an axis.
+type rangeRounder interface {
+	// roundRange returns the range of the
+	// axis for the data range [min, max].
+	roundRange(min, max float64) (float64, float64)
+}
+
+// roundRange returns the range rounded according
+// to dt.Rounding.  Rounding outward changes the
+// spacing of the ticks, so it is repeated until
+// both ends of the range are at major ticks.
+func (dt DefaultTicks) roundRange(min, max float64) (float64, float64) {
+	if dt.Rounding != RoundOutward {
+		return min, max
+	}
+	// eps is the tolerance, relative to the
+	// tick spacing, for a value to be on a tick.
+	const eps = 1e-9
+	for i := 0; i < 10; i++ {
+		_, d := niceTicks(min, max, suggestedTicks, 0)
+		if d == 0 {
+			break
+		}
+		lo := math.Floor(min/d+eps) * d
+		if min > 0 && lo <= 0 {
+			lo = min
+		}
+		hi := math.Ceil(max/d-eps) * d
+		if lo == min && hi == max {
+			break
+		}
+		min, max = lo, hi
+	}
+	return min, max
+}
+
+// NiceTicks returns the tick marks used by DefaultTicks for
+// the range [min, max], with about n labeled major ticks.
+// All of the returned ticks are within the range.  The major
+// ticks are evenly spaced at multiples of the NiceStep nearest
+// to a nth of the range, and are labeled with their values;
+// the minor ticks evenly divide the space between them.
+// The result depends only on min, max and n.  NiceTicks
+// panics if max is less than min.
+func NiceTicks(min, max float64, n int) []Tick {
 	if max < min {
 		panic("illegal range")
 	}
-	tens := math.Pow10(int(math.Floor(math.Log10(max - min))))
-	n := (max - min) / tens
-	for n < SuggestedTicks {
-		tens /= 10
-		n = (max - min) / tens
+	ticks, _ := niceTicks(min, max, n, 0)
+	return ticks
+}
+
+// NiceStep returns the step of 1, 2 or 5 times a power of
+// ten that is nearest to rough, as a ratio, for choosing a
+// round spacing such as the width of histogram bins.  Steps
+// that are not positive and finite are returned unchanged.
+func NiceStep(rough float64) float64 {
+	if !(rough > 0) || math.IsInf(rough, 1) {
+		return rough
+	}
+	e := math.Floor(math.Log10(rough))
+	f := rough / math.Pow10(int(e))
+	// The thresholds are the geometric means
+	// of adjacent steps, so rounding is by ratio.
+	var m float64
+	switch {
+	case f < math.Sqrt2:
+		m = 1
+	case f < math.Sqrt(10):
+		m = 2
+	case f < math.Sqrt(50):
+		m = 5
+	default:
+		m = 10
 	}
+	if e < 0 {
+		// Dividing by an exact power of ten keeps
+		// steps such as 0.05 correctly rounded.
+		return m / math.Pow10(int(-e))
+	}
+	return m * math.Pow10(int(e))
+}
 
-	majorMult := int(n / SuggestedTicks)
-	switch majorMult {
-	case 7:
-		majorMult = 6
-	case 9:
-		majorMult = 8
+// niceTicks returns the ticks of NiceTicks and
+// the distance between the major ticks.  The major
+// ticks are labeled by tickLabeller with expThreshold.
+func niceTicks(min, max float64, suggested int, expThreshold float64) (ticks []Tick, majorDelta float64) {
+	if suggested < 1 {
+		suggested = 1
 	}
-	majorDelta := float64(majorMult) * tens
+	if min == max {
+		return []Tick{{Value: min, Label: fmt.Sprintf("%g", float32(min))}}, 0
+	}
+	majorDelta = NiceStep((max - min) / float64(suggested))
+	label := tickLabeller(min, max, majorDelta, expThreshold)
 	val := math.Floor(min/majorDelta) * majorDelta
 	for val <= max {
 		if val >= min && val <= max {
-			ticks = append(ticks, Tick{Value: val, Label: fmt.Sprintf("%g", float32(val))})
+			ticks = append(ticks, Tick{Value: val, Label: label(val)})
 		}
 		if math.Nextafter(val, val+majorDelta) == val {
 			break
@@ -369,31 +1324,102 @@ func (DefaultTicks) Ticks(min, max float64) (ticks []Tick) {
 		val += majorDelta
 	}
 
+	// Steps of 5 are divided into fifths,
+	// and steps of 1 and 2 into halves.
 	minorDelta := majorDelta / 2
-	switch majorMult {
-	case 3, 6:
-		minorDelta = majorDelta / 3
-	case 5:
+	if m := majorDelta / math.Pow10(int(math.Floor(math.Log10(majorDelta)))); math.Abs(m-5) < 0.5 {
 		minorDelta = majorDelta / 5
 	}
 
-	val = math.Floor(min/minorDelta) * minorDelta
-	for val <= max {
+	ticks = addMinors(ticks, min, max, minorDelta)
+
+	return ticks, majorDelta
+}
+
+// addMinors returns the ticks with minor ticks added at the
+// multiples of minorDelta within the range [min, max] that
+// are not already marked.
+func addMinors(ticks []Tick, min, max, minorDelta float64) []Tick {
+	// The minor ticks are computed as multiples of minorDelta,
+	// rather than by accumulating it, and compared with a
+	// tolerance, so that rounding error neither drops a minor
+	// tick at the end of the range nor duplicates a major tick.
+	tol := minorDelta * 1e-9
+	for i := math.Floor(min / minorDelta); ; i++ {
+		val := i * minorDelta
+		if val > max+tol || i+1 == i {
+			break
+		}
 		found := false
 		for _, t := range ticks {
-			if t.Value == val {
+			if math.Abs(t.Value-val) <= tol {
 				found = true
 			}
 		}
-		if val >= min && val <= max && !found {
-			ticks = append(ticks, Tick{Value: val})
+		if val >= min-tol && !found {
+			ticks = append(ticks, Tick{Value: math.Max(min, math.Min(max, val))})
 		}
-		if math.Nextafter(val, val+minorDelta) == val {
-			break
+	}
+	return ticks
+}
+
+// tickLabeller returns the function giving the labels of
+// major ticks spaced delta apart in the range [min, max].
+// The labels are the values formatted to float32 precision
+// unless that cannot tell adjacent ticks apart, as when an
+// axis is zoomed in to a narrow range far from zero, in which
+// case they are given to the decimal place of delta.  If
+// expThreshold is positive then the float32 labels are all
+// in the notation chosen as for DefaultTicks.ExpThreshold.
+func tickLabeller(min, max, delta, expThreshold float64) func(float64) string {
+	mag := math.Max(math.Abs(min), math.Abs(max))
+	digits := math.Floor(math.Log10(mag)) - math.Floor(math.Log10(delta)) + 1
+	if digits <= 6 {
+		if expThreshold <= 0 {
+			return formatTick
 		}
-		val += minorDelta
+		f := byte('f')
+		if mag >= expThreshold || mag > 0 && mag < 1/expThreshold {
+			f = 'e'
+		}
+		return func(v float64) string { return strconv.FormatFloat(v, f, -1, 32) }
 	}
-	return
+	prec := int(math.Max(0, -math.Floor(math.Log10(delta))))
+	return func(v float64) string { return strconv.FormatFloat(v, 'f', prec, 64) }
+}
+
+// formatTick returns the label of a tick at v,
+// formatted to float32 precision.
+func formatTick(v float64) string {
+	return fmt.Sprintf("%g", float32(v))
+}
+
+// includeEnds returns the ticks with ticks labeled by label
+// added at min and max.  If a major tick is within tol of an
+// end then no tick is added for that end, otherwise any minor
+// ticks within tol of the end are removed.
+func includeEnds(ticks []Tick, min, max, tol float64, label func(float64) string) []Tick {
+	for _, end := range []float64{min, max} {
+		major := false
+		for _, t := range ticks {
+			if !t.IsMinor() && math.Abs(t.Value-end) <= tol {
+				major = true
+				break
+			}
+		}
+		if major {
+			continue
+		}
+		kept := ticks[:0]
+		for _, t := range ticks {
+			if t.IsMinor() && math.Abs(t.Value-end) <= tol {
+				continue
+			}
+			kept = append(kept, t)
+		}
+		ticks = append(kept, Tick{Value: end, Label: label(end)})
+	}
+	return ticks
 }
 
 // LogTicks is suitable for the Tick.Marker field of an Axis,
@@ -424,49 +1450,532 @@ func (LogTicks) Ticks(min, max float64) []Tick {
 	return ticks
 }
 
+// StepTicks is suitable for the Tick.Marker field of an Axis.
+// It returns labeled tick marks at Start + k*Step for each
+// integer k that places the tick within the range.  If that
+// would give more than MaxStepTicks ticks, including the minor
+// ticks, then no ticks are returned.
+type StepTicks struct {
+	// Step is the distance between labeled ticks.
+	// If Step is not positive then no ticks are returned.
+	Step float64
+
+	// Start is the value of one of the labeled ticks.
+	Start float64
+
+	// Minor is the number of intervals into which
+	// the space between labeled ticks is divided by
+	// minor ticks.  If Minor is less than 2 then there
+	// are no minor ticks.
+	Minor int
+
+	// Format is the fmt format of the labels.
+	// If Format is the empty string then the
+	// labels are formatted as by DefaultTicks.
+	Format string
+}
+
+var _ Ticker = StepTicks{}
+
+// MaxStepTicks is the greatest number of ticks
+// returned by StepTicks.
+const MaxStepTicks = 10000
+
+// Ticks returns Ticks in a specified range
+func (s StepTicks) Ticks(min, max float64) []Tick {
+	if !(s.Step > 0) {
+		return nil
+	}
+	div := s.Minor
+	if div < 2 {
+		div = 1
+	}
+	step := s.Step / float64(div)
+	eps := math.Abs(max-min) * TickEpsilon
+	first := math.Ceil((min - eps - s.Start) / step)
+	last := math.Floor((max + eps - s.Start) / step)
+	if !(last-first < MaxStepTicks) {
+		return nil
+	}
+
+	// The ticks are counted with an integer so that the
+	// loop ends even where first is too large for adding
+	// one to change it.  phase is the position of the
+	// first tick among the minor ticks.
+	n := int(last-first) + 1
+	phase := int(math.Mod(first, float64(div)))
+	if phase < 0 {
+		phase += div
+	}
+	var ticks []Tick
+	for i := 0; i < n; i++ {
+		t := Tick{Value: s.Start + (first+float64(i))*step}
+		switch {
+		case (phase+i)%div != 0:
+		case s.Format == "":
+			t.Label = fmt.Sprintf("%g", float32(t.Value))
+		default:
+			t.Label = fmt.Sprintf(s.Format, t.Value)
+		}
+		ticks = append(ticks, t)
+	}
+	return ticks
+}
+
+// TickEpsilon is the tolerance, relative to the size of the
+// range, used by ConstantTicks when deciding whether a tick
+// is within the range of an axis.
+var TickEpsilon = 1e-9
+
 // ConstantTicks is suitable for the Tick.Marker field of an Axis.
-// This function returns the given set of ticks.
+// This function returns the subset of the given ticks that are
+// within the specified range, give or take TickEpsilon.
 type ConstantTicks []Tick
 
 var _ Ticker = ConstantTicks{}
 
 // Ticks returns Ticks in a specified range
-func (ts ConstantTicks) Ticks(float64, float64) []Tick {
+func (ts ConstantTicks) Ticks(min, max float64) []Tick {
+	eps := math.Abs(max-min) * TickEpsilon
+	var ticks []Tick
+	for _, t := range ts {
+		if t.Value < min-eps || t.Value > max+eps {
+			continue
+		}
+		ticks = append(ticks, t)
+	}
+	return ticks
+}
+
+// NewConstantTicks returns the ConstantTicks with major tick
+// marks given by majors, which should have non-empty labels,
+// and minor tick marks at the values given by minors.  Minor
+// values that are also the value of a major tick are ignored.
+func NewConstantTicks(majors []Tick, minors []float64) ConstantTicks {
+	ts := make(ConstantTicks, len(majors), len(majors)+len(minors))
+	copy(ts, majors)
+	isMajor := make(map[float64]bool, len(majors))
+	for _, t := range majors {
+		isMajor[t.Value] = true
+	}
+	for _, v := range minors {
+		if isMajor[v] {
+			continue
+		}
+		ts = append(ts, Tick{Value: v})
+	}
 	return ts
 }
 
+// CategoryAxis is suitable for the Tick.Marker field of an Axis.
+// It gives the names of the categories of a categorical axis, and
+// returns a tick labeled with each name that is within the
+// specified range.  The category at index i of the CategoryAxis
+// is at the value i, so that plotters such as bar charts and box
+// plots are placed on the categories by their integer locations.
+type CategoryAxis []string
+
+var _ Ticker = CategoryAxis{}
+
+// Ticks returns Ticks in a specified range
+func (c CategoryAxis) Ticks(min, max float64) []Tick {
+	ticks := make(ConstantTicks, len(c))
+	for i, name := range c {
+		ticks[i] = Tick{Value: float64(i), Label: name}
+	}
+	return ticks.Ticks(min, max)
+}
+
+// Index returns the value at which the category
+// with the given name is located, or NaN if there
+// is no such category.
+func (c CategoryAxis) Index(name string) float64 {
+	for i, n := range c {
+		if n == name {
+			return float64(i)
+		}
+	}
+	return math.NaN()
+}
+
+// DataTicks is suitable for the Tick.Marker field of an Axis.
+// It returns a labeled tick at each of the given data values
+// that is within the specified range, give or take TickEpsilon,
+// so that every sample of a small data set is marked.
+type DataTicks struct {
+	// Values are the data values at which
+	// ticks are placed.  Repeated values
+	// are given a single tick.
+	Values []float64
+
+	// Labels are the labels of the ticks at the
+	// corresponding Values.  If Labels is shorter
+	// than Values then the remaining ticks are
+	// labeled with their values.
+	Labels []string
+}
+
+var _ Ticker = DataTicks{}
+
+// Ticks returns Ticks in a specified range
+func (dt DataTicks) Ticks(min, max float64) []Tick {
+	eps := math.Abs(max-min) * TickEpsilon
+	seen := make(map[float64]bool, len(dt.Values))
+	var ticks []Tick
+	for i, v := range dt.Values {
+		if v < min-eps || v > max+eps || seen[v] {
+			continue
+		}
+		seen[v] = true
+		label := fmt.Sprintf("%g", float32(v))
+		if i < len(dt.Labels) {
+			label = dt.Labels[i]
+		}
+		ticks = append(ticks, Tick{Value: v, Label: label})
+	}
+	return ticks
+}
+
+// AllTicks is suitable for the Tick.Marker field of an Axis.
+// Unlike ConstantTicks, it returns all of the given ticks
+// regardless of the range of the axis.
+type AllTicks []Tick
+
+var _ Ticker = AllTicks{}
+
+// Ticks returns all of the Ticks.
+func (ts AllTicks) Ticks(float64, float64) []Tick {
+	return ts
+}
+
+// NoTicks is suitable for the Tick.Marker field of an Axis.
+// It returns no tick marks, so the axis is drawn as just its
+// line and label with no space reserved for ticks.
+type NoTicks struct{}
+
+var _ Ticker = NoTicks{}
+
+// Ticks returns no Ticks.
+func (NoTicks) Ticks(float64, float64) []Tick {
+	return nil
+}
+
+// GroupedTicks is suitable for the Tick.Marker field of an Axis.
+// It returns the tick marks of another Ticker with the digits
+// of the integer part of each numeric major tick label grouped
+// in threes, e.g., "1,000,000".
+type GroupedTicks struct {
+	// Ticker returns the tick marks to be relabeled.
+	// If Ticker is nil then DefaultTicks is used.
+	Ticker Ticker
+
+	// Separator is placed between each group of
+	// digits.  If Separator is the empty string
+	// then "," is used.
+	Separator string
+
+	// Decimal is used in place of the decimal point.
+	// If Decimal is the empty string then "." is used.
+	Decimal string
+}
+
+var _ Ticker = GroupedTicks{}
+
+// Ticks returns Ticks in a specified range
+func (g GroupedTicks) Ticks(min, max float64) []Tick {
+	tkr := g.Ticker
+	if tkr == nil {
+		tkr = DefaultTicks{}
+	}
+	sep, dec := g.Separator, g.Decimal
+	if sep == "" {
+		sep = ","
+	}
+	if dec == "" {
+		dec = "."
+	}
+	ticks := tkr.Ticks(min, max)
+	grouped := make([]Tick, len(ticks))
+	for i, t := range ticks {
+		grouped[i] = t
+		if !t.HasLabel() {
+			continue
+		}
+		// The number is that of the label, rather than
+		// the tick's Value, so that the digits of the
+		// label are kept.
+		v, err := strconv.ParseFloat(t.Label, 64)
+		if err != nil {
+			// Leave non-numeric labels alone.
+			continue
+		}
+		grouped[i].Label = groupDigits(strconv.FormatFloat(v, 'f', -1, 64), sep, dec)
+	}
+	return grouped
+}
+
+// PrecisionTicks is suitable for the Tick.Marker field of an Axis.
+// It returns the tick marks of another Ticker with each major
+// tick label formatted with a fixed number of decimal places.
+type PrecisionTicks struct {
+	// Ticker returns the tick marks to be relabeled.
+	// If Ticker is nil then DefaultTicks is used.
+	Ticker Ticker
+
+	// Prec is the number of digits after the
+	// decimal point of each major tick label.
+	Prec int
+}
+
+var _ Ticker = PrecisionTicks{}
+
+// FixedPrecisionTicks returns a PrecisionTicks that labels
+// the major tick marks of DefaultTicks with prec digits after
+// the decimal point.  The minor tick marks are unchanged.
+func FixedPrecisionTicks(prec int) PrecisionTicks {
+	return PrecisionTicks{Ticker: DefaultTicks{}, Prec: prec}
+}
+
+// Ticks returns Ticks in a specified range
+func (p PrecisionTicks) Ticks(min, max float64) []Tick {
+	tkr := p.Ticker
+	if tkr == nil {
+		tkr = DefaultTicks{}
+	}
+	// The ticks are copied, as the Ticker
+	// may return a slice that it keeps.
+	ticks := append([]Tick(nil), tkr.Ticks(min, max)...)
+	for i, t := range ticks {
+		if !t.HasLabel() {
+			continue
+		}
+		ticks[i].Label = strconv.FormatFloat(t.Value, 'f', p.Prec, 64)
+	}
+	return ticks
+}
+
+// ExponentTicks is suitable for the Tick.Marker field of an Axis.
+// It returns the tick marks of another Ticker with each major tick
+// label giving the value divided by a power of ten shared by the
+// whole axis.  The shared power of ten is drawn at the end of the
+// axis as "×10^n", on its own line so that it never overlaps the
+// axis label.
+type ExponentTicks struct {
+	// Ticker returns the tick marks to be relabeled.
+	// If Ticker is nil then DefaultTicks is used.
+	Ticker Ticker
+}
+
+var _ Ticker = ExponentTicks{}
+
+// exponenter wraps the Exponent method.
+type exponenter interface {
+	// Exponent returns the power of ten that is
+	// shared by the tick labels for the given range.
+	Exponent(min, max float64) int
+}
+
+// Exponent returns the power of ten shared by the tick labels
+// for the range [min, max].  It is the exponent of the largest
+// magnitude in the range, when that is at least 1000 or less
+// than 0.01, and zero otherwise.
+func (e ExponentTicks) Exponent(min, max float64) int {
+	m := math.Max(math.Abs(min), math.Abs(max))
+	if m == 0 || math.IsInf(m, 0) || math.IsNaN(m) {
+		return 0
+	}
+	n := int(math.Floor(math.Log10(m)))
+	if n < 3 && n >= -2 {
+		return 0
+	}
+	return n
+}
+
+// Ticks returns Ticks in a specified range
+func (e ExponentTicks) Ticks(min, max float64) []Tick {
+	tkr := e.Ticker
+	if tkr == nil {
+		tkr = DefaultTicks{}
+	}
+	ticks := tkr.Ticks(min, max)
+	n := e.Exponent(min, max)
+	if n == 0 {
+		return ticks
+	}
+	ticks = append([]Tick(nil), ticks...)
+	scale := math.Pow10(n)
+	for i, t := range ticks {
+		if !t.HasLabel() {
+			continue
+		}
+		ticks[i].Label = fmt.Sprintf("%g", float32(t.Value/scale))
+	}
+	return ticks
+}
+
+// groupDigits returns the number s, formatted with
+// a '.' decimal point, with the digits of its integer
+// part separated into groups of three by sep and
+// its decimal point replaced by dec.
+func groupDigits(s, sep, dec string) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	frac := ""
+	if i := strings.Index(s, "."); i >= 0 {
+		s, frac = s[:i], dec+s[i+1:]
+	}
+	var buf bytes.Buffer
+	for i, r := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			buf.WriteString(sep)
+		}
+		buf.WriteRune(r)
+	}
+	return sign + buf.String() + frac
+}
+
 // A Tick is a single tick mark on an axis.
 type Tick struct {
 	// Value is the data value marked by this Tick.
 	Value float64
 
 	// Label is the text to display at the tick mark.
-	// If Label is an empty string then this is a minor
-	// tick mark.
+	// If Label is an empty string and Kind is AutoTick
+	// then this is a minor tick mark.
 	Label string
+
+	// Kind specifies whether the tick is a major or
+	// a minor tick mark.  The zero value, AutoTick,
+	// makes ticks with an empty Label minor and all
+	// others major.
+	Kind TickKind
+
+	// LengthScale, if positive, is the length of the
+	// tick mark as a fraction of the axis's Tick.Length,
+	// replacing the major or minor length given by its
+	// Kind, so that ticks may be drawn in three or more
+	// tiers.  A LengthScale greater than one is treated
+	// as one.
+	LengthScale float64
 }
 
+// TickKind specifies whether a Tick is major or minor.
+type TickKind int
+
+const (
+	// AutoTick specifies that a tick is minor
+	// if and only if its label is empty.
+	AutoTick TickKind = iota
+
+	// MajorTick specifies a major tick, which is
+	// drawn at the major tick length even if its
+	// label is empty.
+	MajorTick
+
+	// MinorTick specifies a minor tick.  The label of
+	// a minor tick, if any, is drawn like that of a
+	// major tick.
+	MinorTick
+)
+
 // IsMinor returns true if this is a minor tick mark.
+// The axes use IsMinor to decide how each tick is
+// drawn, so Tickers that need to tell major and minor
+// ticks apart should use it too.
 func (t Tick) IsMinor() bool {
+	switch t.Kind {
+	case MajorTick:
+		return false
+	case MinorTick:
+		return true
+	}
 	return t.Label == ""
 }
 
+// HasLabel returns true if this tick mark has a label
+// to draw, that is, if its label is not empty.  The Kind
+// of a tick decides only its length, not whether its
+// label is drawn.
+func (t Tick) HasLabel() bool {
+	return t.Label != ""
+}
+
 // lengthOffset returns an offset that should be added to the
 // tick mark's line to accout for its length.  I.e., the start of
-// the line for a minor tick mark must be shifted by half of
-// the length.
-func (t Tick) lengthOffset(len vg.Length) vg.Length {
-	if t.IsMinor() {
-		return len / 2
+// the line for a minor tick mark must be shifted by the difference
+// between the major and minor lengths so that it ends at the
+// axis line.
+func (t Tick) lengthOffset(len, minor vg.Length) vg.Length {
+	if t.LengthScale > 0 {
+		if t.LengthScale >= 1 {
+			return 0
+		}
+		return len * vg.Length(1-t.LengthScale)
+	}
+	if minor == 0 {
+		minor = len / 2
+	}
+	if t.IsMinor() && minor < len {
+		if minor < 0 {
+			minor = 0
+		}
+		return len - minor
 	}
 	return 0
 }
 
+// truncateText returns txt with each of its lines that
+// is wider than max in the given style cut to the longest
+// start that fits with the ellipsis appended.  If not even
+// the ellipsis fits then the line is replaced by it.
+func truncateText(sty draw.TextStyle, txt, ellipsis string, max vg.Length) string {
+	lines := strings.Split(txt, "\n")
+	for i, l := range lines {
+		if sty.Font.Width(l) <= max {
+			continue
+		}
+		rs := []rune(l)
+		n := len(rs)
+		for n > 0 && sty.Font.Width(string(rs[:n])+ellipsis) > max {
+			n--
+		}
+		lines[i] = string(rs[:n]) + ellipsis
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wrapText returns txt with its lines wrapped
+// at spaces so that each line is no wider than
+// max, if possible, when drawn with sty.
+func wrapText(sty draw.TextStyle, txt string, max vg.Length) string {
+	var lines []string
+	for _, line := range strings.Split(txt, "\n") {
+		words := strings.Fields(line)
+		if len(words) == 0 {
+			lines = append(lines, line)
+			continue
+		}
+		cur := words[0]
+		for _, w := range words[1:] {
+			if next := cur + " " + w; sty.Width(next) <= max {
+				cur = next
+				continue
+			}
+			lines = append(lines, cur)
+			cur = w
+		}
+		lines = append(lines, cur)
+	}
+	return strings.Join(lines, "\n")
+}
+
 // tickLabelHeight returns height of the tick mark labels.
 func tickLabelHeight(sty draw.TextStyle, ticks []Tick) vg.Length {
 	maxHeight := vg.Length(0)
 	for _, t := range ticks {
-		if t.IsMinor() {
+		if !t.HasLabel() {
 			continue
 		}
 		h := sty.Height(t.Label)
@@ -481,7 +1990,7 @@ func tickLabelHeight(sty draw.TextStyle, ticks []Tick) vg.Length {
 func tickLabelWidth(sty draw.TextStyle, ticks []Tick) vg.Length {
 	maxWidth := vg.Length(0)
 	for _, t := range ticks {
-		if t.IsMinor() {
+		if !t.HasLabel() {
 			continue
 		}
 		w := sty.Width(t.Label)
@@ -492,6 +2001,36 @@ func tickLabelWidth(sty draw.TextStyle, ticks []Tick) vg.Length {
 	return maxWidth
 }
 
+// anyOverlap returns whether any two of the
+// intervals [lo[i], hi[i]] overlap.
+func anyOverlap(lo, hi []vg.Length) bool {
+	for i := range lo {
+		for j := i + 1; j < len(lo); j++ {
+			if lo[i] < hi[j] && lo[j] < hi[i] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// shiftInside returns the center of a label of the
+// given size centered at x, shifted if necessary so
+// that the label lies between min and max.  If min
+// and max are equal then x is returned unchanged.
+func shiftInside(x, size, min, max vg.Length) vg.Length {
+	if min == max {
+		return x
+	}
+	if x+size/2 > max {
+		x = max - size/2
+	}
+	if x-size/2 < min {
+		x = min + size/2
+	}
+	return x
+}
+
 func log(x float64) float64 {
 	if x <= 0 {
 		panic("Values must be greater than 0 for a log scale.")
@@ -0,0 +1,1276 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot_test
+
+import (
+	"image/color"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+func TestNewConstantTicks(t *testing.T) {
+	got := plot.NewConstantTicks(
+		[]plot.Tick{{Value: 0, Label: "C"}, {Value: 2, Label: "D"}, {Value: 4, Label: "E"}},
+		[]float64{1, 2, 3},
+	)
+	want := plot.ConstantTicks{{Value: 0, Label: "C"}, {Value: 2, Label: "D"}, {Value: 4, Label: "E"}, {Value: 1, Label: ""}, {Value: 3, Label: ""}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected ticks: got:%v want:%v", got, want)
+	}
+}
+
+func TestGroupedTicks(t *testing.T) {
+	tests := []struct {
+		ticker plot.GroupedTicks
+		in     []plot.Tick
+		want   []string
+	}{
+		{
+			in:   []plot.Tick{{Value: 0, Label: "0"}, {Value: 500, Label: ""}, {Value: 1000, Label: "1000"}, {Value: 1e6, Label: "1e+06"}, {Value: -12345.5, Label: "-12345.5"}},
+			want: []string{"0", "", "1,000", "1,000,000", "-12,345.5"},
+		},
+		{
+			ticker: plot.GroupedTicks{Separator: " ", Decimal: ","},
+			in:     []plot.Tick{{Value: 1234.25, Label: "1234.25"}, {Value: 0.5, Label: "0.5"}},
+			want:   []string{"1 234,25", "0,5"},
+		},
+		{
+			in:   []plot.Tick{{Value: 0, Label: "zero"}, {Value: 1000, Label: "one thousand"}},
+			want: []string{"zero", "one thousand"},
+		},
+		{
+			in:   []plot.Tick{{Value: 123456789, Label: "123456789"}, {Value: 0.30000000000000004, Label: "0.3"}},
+			want: []string{"123,456,789", "0.3"},
+		},
+	}
+	for _, test := range tests {
+		test.ticker.Ticker = plot.AllTicks(test.in)
+		var got []string
+		for _, tk := range test.ticker.Ticks(0, 1) {
+			got = append(got, tk.Label)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected labels for %v: got:%q want:%q", test.in, got, test.want)
+		}
+	}
+}
+
+func TestConstantTicks(t *testing.T) {
+	ticks := []plot.Tick{{Value: -1, Label: "-1"}, {Value: 0, Label: "0"}, {Value: 0.5, Label: ""}, {Value: 1 + 1e-12, Label: "1"}, {Value: 2, Label: "2"}}
+	var got []float64
+	for _, tk := range plot.ConstantTicks(ticks).Ticks(0, 1) {
+		got = append(got, tk.Value)
+	}
+	want := []float64{0, 0.5, 1 + 1e-12}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected ConstantTicks: got:%v want:%v", got, want)
+	}
+	if all := plot.AllTicks(ticks).Ticks(0, 1); len(all) != len(ticks) {
+		t.Errorf("unexpected number of AllTicks: got:%d want:%d", len(all), len(ticks))
+	}
+}
+
+func TestFixedPrecisionTicks(t *testing.T) {
+	var majors, minors []string
+	for _, tk := range plot.FixedPrecisionTicks(2).Ticks(0, 10) {
+		if tk.IsMinor() {
+			minors = append(minors, tk.Label)
+			continue
+		}
+		majors = append(majors, tk.Label)
+	}
+	if want := []string{"0.00", "5.00", "10.00"}; !reflect.DeepEqual(majors, want) {
+		t.Errorf("unexpected major tick labels: got:%q want:%q", majors, want)
+	}
+	if len(minors) == 0 {
+		t.Error("no minor ticks")
+	}
+
+	inner := plot.AllTicks{{Value: 1, Label: "1"}, {Value: 1.5}}
+	got := plot.PrecisionTicks{Ticker: inner, Prec: 1}.Ticks(0, 2)
+	if got[0].Label != "1.0" {
+		t.Errorf("unexpected relabeled tick: got:%q want:%q", got[0].Label, "1.0")
+	}
+	if inner[0].Label != "1" {
+		t.Errorf("ticks of the inner Ticker were changed: got:%q want:%q", inner[0].Label, "1")
+	}
+}
+
+func TestDefaultTicksIncludeEnds(t *testing.T) {
+	tests := []struct {
+		min, max float64
+		want     []string
+	}{
+		{min: 0.3, max: 9.7, want: []string{"0.3", "9.7"}},
+		{min: 0, max: 10, want: nil},
+		{min: 0.01, max: 8.01, want: []string{"0.01"}},
+	}
+	for _, test := range tests {
+		def := make(map[float64]bool)
+		for _, tk := range (plot.DefaultTicks{}).Ticks(test.min, test.max) {
+			def[tk.Value] = true
+		}
+		var got []string
+		for _, tk := range (plot.DefaultTicks{IncludeEnds: true}).Ticks(test.min, test.max) {
+			if tk.IsMinor() && !def[tk.Value] {
+				t.Errorf("unexpected new minor tick at %g for range [%g,%g]", tk.Value, test.min, test.max)
+			}
+			if !def[tk.Value] {
+				got = append(got, tk.Label)
+			}
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected end ticks for range [%g,%g]: got:%q want:%q", test.min, test.max, got, test.want)
+		}
+	}
+}
+
+func TestMinorTickLength(t *testing.T) {
+	tests := []struct {
+		major, minor vg.Length
+		want         vg.Length
+	}{
+		{major: 9, minor: 3, want: 3},
+		{major: 8, minor: 4, want: 4},
+		{major: 6, minor: 10, want: 6},
+		{major: 20, minor: 0, want: 10},
+		{major: 8, minor: -1, want: 0},
+	}
+	for _, test := range tests {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("failed to create plot: %v", err)
+		}
+		p.HideY()
+		p.X.Min, p.X.Max = 0, 1
+		p.Y.Min, p.Y.Max = 0, 1
+		p.X.Tick.Length = test.major
+		p.X.Tick.MinorLength = test.minor
+		p.X.Tick.Marker = plot.ConstantTicks([]plot.Tick{{Value: 0, Label: "0"}, {Value: 0.5, Label: ""}, {Value: 1, Label: "1"}})
+
+		r := recorder.New(72)
+		p.Draw(draw.NewCanvas(r, 100, 100))
+
+		// The tick marks are the first stroke with
+		// a line for each of the three ticks.
+		var ticks vg.Path
+		for _, a := range r.Actions {
+			if s, ok := a.(*recorder.Stroke); ok && len(s.Path) == 6 {
+				ticks = s.Path
+				break
+			}
+		}
+		if ticks == nil {
+			t.Fatalf("no tick marks drawn for major=%v minor=%v", test.major, test.minor)
+		}
+		for i := 0; i < len(ticks); i += 2 {
+			start, end := ticks[i], ticks[i+1]
+			if start.X != end.X {
+				t.Errorf("tick mark %d is not vertical: %v", i/2, ticks[i:i+2])
+			}
+			want := test.major
+			if i == 2 {
+				want = test.want
+			}
+			if got := end.Y - start.Y; math.Abs(float64(got-want)) > 1e-9 {
+				t.Errorf("unexpected length of tick mark %d for major=%v minor=%v: got:%v want:%v",
+					i/2, test.major, test.minor, got, want)
+			}
+			if end.Y != ticks[1].Y {
+				t.Errorf("tick mark %d does not end at the axis line: got:%v want:%v", i/2, end.Y, ticks[1].Y)
+			}
+		}
+	}
+}
+
+func TestTickKind(t *testing.T) {
+	kinds := []struct {
+		tick plot.Tick
+		want bool
+	}{
+		{tick: plot.Tick{Value: 0, Label: "0"}, want: false},
+		{tick: plot.Tick{Value: 0}, want: true},
+		{tick: plot.Tick{Value: 0, Kind: plot.MajorTick}, want: false},
+		{tick: plot.Tick{Value: 0, Label: "0", Kind: plot.MinorTick}, want: true},
+	}
+	for _, k := range kinds {
+		if got := k.tick.IsMinor(); got != k.want {
+			t.Errorf("unexpected IsMinor for %+v: got:%t want:%t", k.tick, got, k.want)
+		}
+		if got, want := k.tick.HasLabel(), k.tick.Label != ""; got != want {
+			t.Errorf("unexpected HasLabel for %+v: got:%t want:%t", k.tick, got, want)
+		}
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.HideY()
+	p.X.Min, p.X.Max = 0, 1
+	p.Y.Min, p.Y.Max = 0, 1
+	p.X.Tick.Length = 8
+	p.X.Tick.MinorLength = 4
+	p.X.Tick.Marker = plot.ConstantTicks([]plot.Tick{
+		{Value: 0, Label: "0"},
+		{Value: 0.5, Label: "half", Kind: plot.MinorTick},
+		{Value: 1, Kind: plot.MajorTick},
+	})
+
+	r := recorder.New(72)
+	p.Draw(draw.NewCanvas(r, 100, 100))
+
+	var ticks vg.Path
+	labels := make(map[string]bool)
+	for _, a := range r.Actions {
+		switch a := a.(type) {
+		case *recorder.Stroke:
+			if ticks == nil && len(a.Path) == 6 {
+				ticks = a.Path
+			}
+		case *recorder.FillString:
+			labels[a.String] = true
+		}
+	}
+	if ticks == nil {
+		t.Fatal("no tick marks drawn")
+	}
+	for i, want := range []vg.Length{8, 4, 8} {
+		if got := ticks[2*i+1].Y - ticks[2*i].Y; math.Abs(float64(got-want)) > 1e-9 {
+			t.Errorf("unexpected length of tick mark %d: got:%v want:%v", i, got, want)
+		}
+	}
+	if !labels["0"] || !labels["half"] {
+		t.Errorf("missing tick labels: got:%v", labels)
+	}
+}
+
+func TestAxisTickMarks(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.X.Min, p.X.Max = 100, 0
+	got := p.X.TickMarks()
+	want := plot.DefaultTicks{}.Ticks(0, 100)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected tick marks: got:%v want:%v", got, want)
+	}
+	if p.X.Min != 100 || p.X.Max != 0 {
+		t.Errorf("TickMarks changed the axis range: got:[%g,%g] want:[100,0]", p.X.Min, p.X.Max)
+	}
+}
+
+func TestTickLabelAlign(t *testing.T) {
+	labels := func(align func(*plot.Plot)) map[string]*recorder.FillString {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("failed to create plot: %v", err)
+		}
+		p.X.Min, p.X.Max = 0, 1
+		p.Y.Min, p.Y.Max = 0, 1
+		p.X.Tick.Marker = plot.ConstantTicks([]plot.Tick{{Value: 0.5, Label: "x"}})
+		p.Y.Tick.Marker = plot.ConstantTicks([]plot.Tick{{Value: 0.5, Label: "y"}})
+		align(p)
+		r := recorder.New(72)
+		p.Draw(draw.NewCanvas(r, 100, 100))
+		got := make(map[string]*recorder.FillString)
+		for _, a := range r.Actions {
+			if s, ok := a.(*recorder.FillString); ok {
+				got[s.String] = s
+			}
+		}
+		return got
+	}
+	def := labels(func(*plot.Plot) {})
+	got := labels(func(p *plot.Plot) {
+		p.X.Tick.LabelXAlign = 0
+		p.X.Tick.LabelYOffset = 3
+		p.Y.Tick.LabelXOffset = -2
+	})
+
+	fnt, err := vg.MakeFont(def["x"].Font, def["x"].Size)
+	if err != nil {
+		t.Fatalf("failed to make font: %v", err)
+	}
+	if dx := got["x"].X - def["x"].X; math.Abs(float64(dx-fnt.Width("x")/2)) > 1e-9 {
+		t.Errorf("unexpected X label shift for LabelXAlign=0: got:%v want:%v", dx, fnt.Width("x")/2)
+	}
+	if dy := got["x"].Y - def["x"].Y; math.Abs(float64(dy-3)) > 1e-9 {
+		t.Errorf("unexpected X label shift for LabelYOffset=3: got:%v want:3", dy)
+	}
+	if dx := got["y"].X - def["y"].X; math.Abs(float64(dx+2)) > 1e-9 {
+		t.Errorf("unexpected Y label shift for LabelXOffset=-2: got:%v want:-2", dx)
+	}
+}
+
+func TestAxisZoomPan(t *testing.T) {
+	const tol = 1e-12
+	tests := []struct {
+		scale    plot.Normalizer
+		min, max float64
+		zoom     func(*plot.Axis)
+		want     [2]float64
+	}{
+		{
+			scale: plot.LinearScale{}, min: 0, max: 10,
+			zoom: func(a *plot.Axis) { a.Zoom(2, 5) },
+			want: [2]float64{2.5, 7.5},
+		},
+		{
+			scale: plot.LinearScale{}, min: 0, max: 10,
+			zoom: func(a *plot.Axis) { a.Zoom(0.5, 0) },
+			want: [2]float64{0, 20},
+		},
+		{
+			scale: plot.LinearScale{}, min: 0, max: 10,
+			zoom: func(a *plot.Axis) { a.Pan(-3) },
+			want: [2]float64{-3, 7},
+		},
+		{
+			scale: plot.LogScale{}, min: 1, max: 10000,
+			zoom: func(a *plot.Axis) { a.Zoom(2, 100) },
+			want: [2]float64{10, 1000},
+		},
+		{
+			scale: plot.LogScale{}, min: 1, max: 100,
+			zoom: func(a *plot.Axis) { a.Pan(1) },
+			want: [2]float64{10, 1000},
+		},
+		{
+			scale: plot.LinearScale{}, min: 1, max: 10,
+			zoom: func(a *plot.Axis) { a.Zoom(0, 5) },
+			want: [2]float64{1, 10},
+		},
+		{
+			scale: plot.LinearScale{}, min: 1, max: 10,
+			zoom: func(a *plot.Axis) { a.Zoom(-2, 5) },
+			want: [2]float64{1, 10},
+		},
+		{
+			scale: plot.LogScale{}, min: 1, max: 100,
+			zoom: func(a *plot.Axis) { a.Zoom(2, 0) },
+			want: [2]float64{1, 100},
+		},
+		{
+			scale: plot.LogScale{}, min: 1, max: 100,
+			zoom: func(a *plot.Axis) { a.Zoom(-1, 10) },
+			want: [2]float64{1, 100},
+		},
+	}
+	for i, test := range tests {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("failed to create plot: %v", err)
+		}
+		a := &p.X
+		a.Scale = test.scale
+		a.Min, a.Max = test.min, test.max
+		test.zoom(a)
+		if math.Abs(a.Min-test.want[0]) > tol*math.Abs(test.want[0]) || math.Abs(a.Max-test.want[1]) > tol*math.Abs(test.want[1]) {
+			t.Errorf("unexpected range for test %d: got:[%g,%g] want:%g", i, a.Min, a.Max, test.want)
+		}
+	}
+}
+
+func TestLabelMaxWidth(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 1
+	p.Y.Min, p.Y.Max = 0, 1
+	p.X.Label.Text = "one two three four"
+	c := draw.NewCanvas(recorder.New(72), 200, 200)
+	unwrapped := p.DataCanvas(c)
+
+	p.X.Label.MaxWidth = p.X.Label.Width("one two three")
+	r := recorder.New(72)
+	c = draw.NewCanvas(r, 200, 200)
+	p.Draw(c)
+	var got []string
+	for _, a := range r.Actions {
+		if fs, ok := a.(*recorder.FillString); ok && fs.Font == p.X.Label.Font.Name() && fs.Size == p.X.Label.Font.Size {
+			got = append(got, fs.String)
+		}
+	}
+	if want := []string{"one two three", "four"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected label lines: got:%q want:%q", got, want)
+	}
+	wrapped := p.DataCanvas(c)
+	if d, want := wrapped.Min.Y-unwrapped.Min.Y, p.X.Label.Font.Extents().Height; math.Abs(float64(d-want)) > 1e-9 {
+		t.Errorf("unexpected space reserved for wrapped label: got:%v want:%v", d, want)
+	}
+}
+
+func TestAxisLineWidthZero(t *testing.T) {
+	for _, width := range []vg.Length{1, 0} {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("failed to create plot: %v", err)
+		}
+		p.HideY()
+		p.X.Min, p.X.Max = 0, 1
+		p.Y.Min, p.Y.Max = 0, 1
+		p.X.Width = width
+		p.X.Tick.Marker = plot.ConstantTicks([]plot.Tick{{Value: 0, Label: "0"}, {Value: 1, Label: "1"}})
+
+		r := recorder.New(72)
+		p.Draw(draw.NewCanvas(r, 100, 100))
+		var ticks, lines int
+		for _, a := range r.Actions {
+			s, ok := a.(*recorder.Stroke)
+			if !ok {
+				continue
+			}
+			switch len(s.Path) {
+			case 4:
+				ticks++
+			case 2:
+				lines++
+			}
+		}
+		if ticks != 1 {
+			t.Errorf("unexpected number of tick strokes for width %v: got:%d want:1", width, ticks)
+		}
+		if want := int(width); lines != want {
+			t.Errorf("unexpected number of axis lines for width %v: got:%d want:%d", width, lines, want)
+		}
+	}
+}
+
+func TestAxisDrawLine(t *testing.T) {
+	var sizes [2]vg.Length
+	for i, line := range []bool{true, false} {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("failed to create plot: %v", err)
+		}
+		p.HideY()
+		p.X.Min, p.X.Max = 0, 1
+		p.Y.Min, p.Y.Max = 0, 1
+		p.X.Width = 4
+		p.X.DrawLine = line
+		p.X.Tick.Marker = plot.ConstantTicks([]plot.Tick{{Value: 0, Label: "0"}, {Value: 1, Label: "1"}})
+
+		r := recorder.New(72)
+		c := draw.NewCanvas(r, 100, 100)
+		p.Draw(c)
+		sizes[i] = p.DataCanvas(c).Min.Y
+		var ticks, lines int
+		var labels []string
+		for _, a := range r.Actions {
+			switch a := a.(type) {
+			case *recorder.Stroke:
+				switch len(a.Path) {
+				case 4:
+					ticks++
+				case 2:
+					lines++
+				}
+			case *recorder.FillString:
+				labels = append(labels, a.String)
+			}
+		}
+		if ticks != 1 {
+			t.Errorf("unexpected number of tick strokes with DrawLine=%t: got:%d want:1", line, ticks)
+		}
+		if want := []string{"0", "1"}; !reflect.DeepEqual(labels, want) {
+			t.Errorf("unexpected tick labels with DrawLine=%t: got:%q want:%q", line, labels, want)
+		}
+		want := 0
+		if line {
+			want = 1
+		}
+		if lines != want {
+			t.Errorf("unexpected number of axis lines with DrawLine=%t: got:%d want:%d", line, lines, want)
+		}
+	}
+	// No space is reserved for the line that is not drawn.
+	if got, want := sizes[0]-sizes[1], vg.Length(2); math.Abs(float64(got-want)) > 1e-9 {
+		t.Errorf("unexpected space for the axis line: got:%v want:%v", got, want)
+	}
+}
+
+func TestNiceTicks(t *testing.T) {
+	for _, test := range []struct {
+		min, max float64
+		n        int
+	}{
+		{0, 10, 3},
+		{-3.7, 12.2, 5},
+		{0.001, 0.0173, 4},
+		{5, 5, 3},
+		{1e6, 1e6 + 7, 10},
+	} {
+		ticks := plot.NiceTicks(test.min, test.max, test.n)
+		if !reflect.DeepEqual(ticks, plot.NiceTicks(test.min, test.max, test.n)) {
+			t.Errorf("ticks are not deterministic for [%g,%g]", test.min, test.max)
+		}
+		var majors []float64
+		for _, tk := range ticks {
+			if tk.Value < test.min || tk.Value > test.max {
+				t.Errorf("tick %v out of range [%g,%g]", tk, test.min, test.max)
+			}
+			if !tk.IsMinor() {
+				majors = append(majors, tk.Value)
+			}
+		}
+		if len(majors) == 0 {
+			t.Errorf("no major ticks for [%g,%g]", test.min, test.max)
+		}
+		for i := 2; i < len(majors); i++ {
+			d0, d1 := majors[i-1]-majors[i-2], majors[i]-majors[i-1]
+			if math.Abs(d1-d0) > 1e-9*math.Abs(d0) {
+				t.Errorf("major ticks are not evenly spaced for [%g,%g]: %v", test.min, test.max, majors)
+				break
+			}
+		}
+	}
+	if got, want := plot.NiceTicks(0, 10, 3), (plot.DefaultTicks{}).Ticks(0, 10); !reflect.DeepEqual(got, want) {
+		t.Errorf("NiceTicks does not match DefaultTicks: got:%v want:%v", got, want)
+	}
+}
+
+func TestStepTicks(t *testing.T) {
+	tests := []struct {
+		ticker   plot.StepTicks
+		min, max float64
+		want     []plot.Tick
+	}{
+		{
+			ticker: plot.StepTicks{Step: 50},
+			min:    -20, max: 120,
+			want: []plot.Tick{{Value: 0, Label: "0"}, {Value: 50, Label: "50"}, {Value: 100, Label: "100"}},
+		},
+		{
+			ticker: plot.StepTicks{Step: 2, Start: 1, Minor: 2, Format: "%.1f"},
+			min:    0, max: 4,
+			want: []plot.Tick{{Value: 0, Label: ""}, {Value: 1, Label: "1.0"}, {Value: 2, Label: ""}, {Value: 3, Label: "3.0"}, {Value: 4, Label: ""}},
+		},
+		{
+			ticker: plot.StepTicks{Step: 0.1},
+			min:    0.3, max: 0.5,
+			want: []plot.Tick{{Value: 0.30000000000000004, Label: "0.3"}, {Value: 0.4, Label: "0.4"}, {Value: 0.5, Label: "0.5"}},
+		},
+		{
+			ticker: plot.StepTicks{Step: 2, Start: 1, Minor: 2, Format: "%.1f"},
+			min:    -4, max: -1,
+			want: []plot.Tick{{Value: -4, Label: ""}, {Value: -3, Label: "-3.0"}, {Value: -2, Label: ""}, {Value: -1, Label: "-1.0"}},
+		},
+		{
+			ticker: plot.StepTicks{Step: 0},
+			min:    0, max: 1,
+		},
+		{
+			ticker: plot.StepTicks{Step: 1},
+			min:    1e17, max: 1e17,
+			want: []plot.Tick{{Value: 1e17, Label: "1e+17"}},
+		},
+		{
+			ticker: plot.StepTicks{Step: 1e-9},
+			min:    0, max: 1,
+		},
+	}
+	for _, test := range tests {
+		got := test.ticker.Ticks(test.min, test.max)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected ticks for %+v in [%g,%g]: got:%v want:%v", test.ticker, test.min, test.max, got, test.want)
+		}
+	}
+}
+
+func TestExponentTicksLabelOverlap(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.HideY()
+	p.X.Min, p.X.Max = 0, 1e6
+	p.Y.Min, p.Y.Max = 0, 1
+	p.X.Label.Text = "a rather long axis label that spans most of the axis"
+	p.X.Tick.Marker = plot.ExponentTicks{}
+
+	r := recorder.New(72)
+	c := draw.NewCanvas(r, 300, 200)
+	p.Draw(c)
+	da := p.DataCanvas(c)
+
+	var label, exp *recorder.FillString
+	var ticks []string
+	for _, a := range r.Actions {
+		fs, ok := a.(*recorder.FillString)
+		if !ok {
+			continue
+		}
+		switch fs.String {
+		case p.X.Label.Text:
+			label = fs
+		case "×10^6":
+			exp = fs
+		default:
+			ticks = append(ticks, fs.String)
+		}
+	}
+	if label == nil || exp == nil {
+		t.Fatalf("missing label or exponent: label:%v exponent:%v", label, exp)
+	}
+	if want := []string{"0", "0.5", "1"}; !reflect.DeepEqual(ticks, want) {
+		t.Errorf("unexpected tick labels: got:%q want:%q", ticks, want)
+	}
+
+	lsty, esty := p.X.Label.TextStyle, p.X.Tick.Label
+	// The line boxes of the label and exponent must be disjoint.
+	top := label.Y + lsty.Font.Extents().Descent + lsty.Height(label.String)
+	if bottom := exp.Y + esty.Font.Extents().Descent; top > bottom {
+		t.Errorf("exponent overlaps label: label top:%v exponent bottom:%v", top, bottom)
+	}
+	if right := exp.X + esty.Width(exp.String); math.Abs(float64(right-da.Max.X)) > 1e-9 {
+		t.Errorf("exponent not at the end of the axis: got:%v want:%v", right, da.Max.X)
+	}
+	if mid := label.X + lsty.Width(label.String)/2; math.Abs(float64(mid-da.Center().X)) > 1e-9 {
+		t.Errorf("label not centered: got:%v want:%v", mid, da.Center().X)
+	}
+
+	inner := plot.AllTicks{{Value: 2000, Label: "2000"}, {Value: 2500}}
+	got := plot.ExponentTicks{Ticker: inner}.Ticks(0, 3000)
+	if got[0].Label != "2" {
+		t.Errorf("unexpected relabeled tick: got:%q want:%q", got[0].Label, "2")
+	}
+	if inner[0].Label != "2000" {
+		t.Errorf("ticks of the inner Ticker were changed: got:%q want:%q", inner[0].Label, "2000")
+	}
+}
+
+func TestNoTicks(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 1
+	p.Y.Min, p.Y.Max = 0, 1
+	p.X.Tick.Marker = plot.NoTicks{}
+	p.Y.Tick.Marker = plot.NoTicks{}
+
+	r := recorder.New(72)
+	c := draw.NewCanvas(r, 100, 100)
+	p.Draw(c)
+	for _, a := range r.Actions {
+		if s, ok := a.(*recorder.Stroke); ok && len(s.Path) != 2 {
+			t.Errorf("unexpected non-line stroke: %v", s)
+		}
+	}
+	da := p.DataCanvas(c)
+	if want := p.X.Width/2 + p.X.Padding; da.Min.Y != want {
+		t.Errorf("unexpected X axis size: got:%v want:%v", da.Min.Y, want)
+	}
+	if want := p.Y.Width/2 + p.Y.Padding; da.Min.X != want {
+		t.Errorf("unexpected Y axis size: got:%v want:%v", da.Min.X, want)
+	}
+}
+
+func TestEndTickLabelsInside(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.HideY()
+	p.X.Min, p.X.Max = 0, 1
+	p.Y.Min, p.Y.Max = 0, 1
+	p.X.Tick.Marker = plot.ConstantTicks([]plot.Tick{{Value: 0, Label: "1000000000"}, {Value: 1, Label: "2000000000"}})
+
+	const w = 200
+	r := recorder.New(72)
+	p.Draw(draw.NewCanvas(r, w, 100))
+	var n int
+	for _, a := range r.Actions {
+		fs, ok := a.(*recorder.FillString)
+		if !ok {
+			continue
+		}
+		n++
+		if right := fs.X + p.X.Tick.Label.Width(fs.String); fs.X < 0 || right > w {
+			t.Errorf("tick label %q clipped: spans [%v, %v] of [0, %v]", fs.String, fs.X, right, w)
+		}
+	}
+	if n != 2 {
+		t.Errorf("unexpected number of tick labels: got:%d want:2", n)
+	}
+}
+
+func TestDefaultTicksRounding(t *testing.T) {
+	majors := func(ticks []plot.Tick) []float64 {
+		var vs []float64
+		for _, t := range ticks {
+			if !t.IsMinor() {
+				vs = append(vs, t.Value)
+			}
+		}
+		return vs
+	}
+
+	in := plot.DefaultTicks{}.Ticks(0.3, 9.7)
+	for _, tk := range in {
+		if tk.Value < 0.3 || tk.Value > 9.7 {
+			t.Errorf("inward tick outside of range: %v", tk.Value)
+		}
+	}
+	// The positive minimum is not rounded to zero.
+	out := plot.DefaultTicks{Rounding: plot.RoundOutward}.Ticks(0.3, 9.7)
+	if got, want := majors(out), []float64{5, 10}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected outward major ticks: got:%v want:%v", got, want)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.X.Min, p.X.Max = -3.2, 47
+	p.Y.Min, p.Y.Max = 0.3, 9.7
+	p.X.Tick.Marker = plot.DefaultTicks{Rounding: plot.RoundOutward}
+	p.Y.Tick.Marker = plot.DefaultTicks{Rounding: plot.RoundOutward}
+	c := draw.NewCanvas(recorder.New(72), 100, 100)
+	for i := 0; i < 2; i++ {
+		p.DataCanvas(c)
+		if p.X.Min != -20 || p.X.Max != 60 {
+			t.Errorf("unexpected X range after %d calls: got:[%v, %v] want:[-20, 60]", i+1, p.X.Min, p.X.Max)
+		}
+		if p.Y.Min != 0.3 || p.Y.Max != 10 {
+			t.Errorf("unexpected Y range after %d calls: got:[%v, %v] want:[0.3, 10]", i+1, p.Y.Min, p.Y.Max)
+		}
+	}
+
+	// Log scale axes are not rounded.
+	p.Y.Scale = plot.LogScale{}
+	p.Y.Min, p.Y.Max = 1, 1000
+	p.Draw(c)
+	if p.Y.Min != 1 || p.Y.Max != 1000 {
+		t.Errorf("unexpected log Y range: got:[%v, %v] want:[1, 1000]", p.Y.Min, p.Y.Max)
+	}
+}
+
+func TestTickLabelAndMarkColors(t *testing.T) {
+	var (
+		labelColor = color.RGBA{R: 255, A: 255}
+		markColor  = color.RGBA{B: 255, A: 255}
+		lineColor  = color.RGBA{G: 255, A: 255}
+	)
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 1
+	p.Y.Min, p.Y.Max = 0, 1
+	for _, a := range []*plot.Axis{&p.X, &p.Y} {
+		a.Tick.Marker = plot.ConstantTicks([]plot.Tick{{Value: 0, Label: "0"}, {Value: 1, Label: "1"}})
+		a.Tick.Label.Color = labelColor
+		a.Tick.Color = markColor
+		a.Color = lineColor
+	}
+
+	r := recorder.New(72)
+	p.Draw(draw.NewCanvas(r, 100, 100))
+	var cur color.Color
+	var labels, marks, lines int
+	for _, a := range r.Actions {
+		switch a := a.(type) {
+		case *recorder.SetColor:
+			cur = a.Color
+		case *recorder.FillString:
+			labels++
+			if cur != labelColor {
+				t.Errorf("tick label %q drawn with color %v, want %v", a.String, cur, labelColor)
+			}
+		case *recorder.Stroke:
+			switch len(a.Path) {
+			case 4:
+				marks++
+				if cur != markColor {
+					t.Errorf("tick marks drawn with color %v, want %v", cur, markColor)
+				}
+			case 2:
+				lines++
+				if cur != lineColor {
+					t.Errorf("axis line drawn with color %v, want %v", cur, lineColor)
+				}
+			}
+		}
+	}
+	if labels != 4 || marks != 2 || lines != 2 {
+		t.Errorf("unexpected number of actions: got %d labels, %d tick strokes, %d lines; want 4, 2, 2", labels, marks, lines)
+	}
+}
+
+func TestDataTicks(t *testing.T) {
+	dt := plot.DataTicks{
+		Values: []float64{0.5, 1.25, 3, 1.25, 7},
+		Labels: []string{"a", "b"},
+	}
+	got := dt.Ticks(0, 5)
+	want := []plot.Tick{{Value: 0.5, Label: "a"}, {Value: 1.25, Label: "b"}, {Value: 3, Label: "3"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected data ticks: got:%v want:%v", got, want)
+	}
+}
+
+func TestDefaultTicksZoom(t *testing.T) {
+	prevStep := math.Inf(1)
+	for _, zoom := range []float64{0.1, 1, 10, 1e7} {
+		a := plot.Axis{Min: 0, Max: 100, Scale: plot.LinearScale{}}
+		a.Zoom(zoom, 50)
+		var majors []plot.Tick
+		for _, tk := range (plot.DefaultTicks{}).Ticks(a.Min, a.Max) {
+			if !tk.IsMinor() {
+				majors = append(majors, tk)
+			}
+		}
+		if len(majors) < 2 || len(majors) > 6 {
+			t.Fatalf("unexpected number of major ticks at zoom %g: got:%d want:2-6", zoom, len(majors))
+		}
+		step := majors[1].Value - majors[0].Value
+		if !(step < prevStep) {
+			t.Errorf("tick step did not decrease at zoom %g: got:%g previous:%g", zoom, step, prevStep)
+		}
+		prevStep = step
+		if n := (a.Max - a.Min) / step; n < 2 || n > 6 {
+			t.Errorf("unexpected tick density at zoom %g: %g steps across the range", zoom, n)
+		}
+		seen := make(map[string]bool)
+		for _, tk := range majors {
+			if seen[tk.Label] {
+				t.Errorf("duplicate tick label at zoom %g: %q in %v", zoom, tk.Label, majors)
+			}
+			seen[tk.Label] = true
+		}
+	}
+}
+
+func TestDefaultTicksMinorEnds(t *testing.T) {
+	// The nice-number algorithm gives majors at 0, 0.5, 1
+	// and 1.5 with minors every 0.1, so the tick at the upper
+	// end of the range is a minor tick.  Summing the minor
+	// step accumulates enough rounding error to lose it.
+	ticks := plot.DefaultTicks{}.Ticks(0, 1.6)
+	var end *plot.Tick
+	for i, tk := range ticks {
+		if tk.Value == 1.6 {
+			end = &ticks[i]
+		}
+		for _, other := range ticks[:i] {
+			if math.Abs(tk.Value-other.Value) < 1e-9 {
+				t.Errorf("duplicate ticks at %g: %v and %v", tk.Value, other, tk)
+			}
+		}
+	}
+	if end == nil || !end.IsMinor() {
+		t.Fatalf("no minor tick at the end of the range: %v", ticks)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.HideY()
+	p.X.Min, p.X.Max = 0, 1.6
+	p.Y.Min, p.Y.Max = 0, 1
+	r := recorder.New(72)
+	p.Draw(draw.NewCanvas(r, 200, 100))
+	for _, a := range r.Actions {
+		if s, ok := a.(*recorder.Stroke); ok && len(s.Path) == 2*len(ticks) {
+			return
+		}
+	}
+	t.Errorf("tick marks not drawn for all %d ticks", len(ticks))
+}
+
+func TestBrokenScale(t *testing.T) {
+	s := plot.NewBrokenScale(plot.AxisBreak{Min: 10, Max: 1000})
+	const min, max = 0, 1010
+	for _, test := range []struct{ x, want float64 }{
+		{x: 0, want: 0},
+		{x: 10, want: 0.49},
+		{x: 505, want: 0.5},
+		{x: 1000, want: 0.51},
+		{x: 1010, want: 1},
+	} {
+		if got := s.Normalize(min, max, test.x); math.Abs(got-test.want) > 1e-12 {
+			t.Errorf("Normalize(%g) = %g, want %g", test.x, got, test.want)
+		}
+	}
+	for _, tk := range s.Ticks(min, max) {
+		if tk.Value > 10 && tk.Value < 1000 {
+			t.Errorf("tick %v within the break", tk)
+		}
+	}
+
+	// The axis line is drawn in two pieces with
+	// the zigzag break symbol between them.
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.HideY()
+	p.X.Min, p.X.Max = min, max
+	p.X.Scale, p.X.Tick.Marker = s, s
+	r := recorder.New(72)
+	p.Draw(draw.NewCanvas(r, 200, 100))
+	var line *recorder.Stroke
+	for _, a := range r.Actions {
+		if s, ok := a.(*recorder.Stroke); ok {
+			line = s
+		}
+	}
+	if line == nil || len(line.Path) != 2+4+2 {
+		t.Errorf("axis line not drawn with a break: %v", line)
+	}
+}
+
+func TestTickLabelMaxWidth(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	names := plot.ConstantTicks{
+		{Value: 0, Label: "short"},
+		{Value: 1, Label: "a very long category name"},
+	}
+	p.X.Min, p.X.Max = 0, 1
+	p.X.Tick.Marker = names
+	p.X.Tick.LabelMaxWidth = p.X.Tick.Label.Width("a very long")
+
+	ticks := p.X.TickMarks()
+	if ticks[0].Label != "short" {
+		t.Errorf("short label changed to %q", ticks[0].Label)
+	}
+	l := ticks[1].Label
+	if !strings.HasPrefix(l, "a very") || !strings.HasSuffix(l, "…") {
+		t.Errorf("long label truncated to %q", l)
+	}
+	if w := p.X.Tick.Label.Width(l); w > p.X.Tick.LabelMaxWidth {
+		t.Errorf("truncated label %q is %v wide, more than %v", l, w, p.X.Tick.LabelMaxWidth)
+	}
+	if names[1].Label != "a very long category name" {
+		t.Errorf("marker's label changed to %q", names[1].Label)
+	}
+}
+
+func TestHideTickMarksAndLabels(t *testing.T) {
+	grid := color.RGBA{R: 0x80, A: 0xff}
+	var full vg.Length
+	for _, test := range []struct {
+		marks, labels bool
+	}{
+		{marks: true, labels: true},
+		{marks: false, labels: true},
+		{marks: true, labels: false},
+		{marks: false, labels: false},
+	} {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("failed to create plot: %v", err)
+		}
+		p.HideY()
+		p.X.Min, p.X.Max = 0, 10
+		p.Y.Min, p.Y.Max = 0, 10
+		p.X.GridStyle = draw.LineStyle{Color: grid, Width: 1}
+		p.X.Tick.HideMarks = !test.marks
+		p.X.Tick.HideLabels = !test.labels
+
+		c := draw.NewCanvas(recorder.New(72), 200, 100)
+		h := p.DataCanvas(c).Min.Y - c.Min.Y
+		want := full
+		if test.marks && test.labels {
+			full = h
+			want = h
+		}
+		if !test.marks {
+			want -= p.X.Tick.Length
+		}
+		if !test.labels {
+			want -= p.X.Tick.Label.Height("0")
+		}
+		if math.Abs(float64(h-want)) > 1e-9 {
+			t.Errorf("unexpected axis size for marks:%t labels:%t: got:%v want:%v", test.marks, test.labels, h, want)
+		}
+
+		r := recorder.New(72)
+		p.Draw(draw.NewCanvas(r, 200, 100))
+		var labels, grids int
+		for _, a := range r.Actions {
+			switch a := a.(type) {
+			case *recorder.FillString:
+				labels++
+			case *recorder.SetColor:
+				if a.Color == grid {
+					grids++
+				}
+			}
+		}
+		if (labels > 0) != test.labels {
+			t.Errorf("unexpected %d labels drawn for marks:%t labels:%t", labels, test.marks, test.labels)
+		}
+		if grids == 0 {
+			t.Errorf("grid lines not drawn for marks:%t labels:%t", test.marks, test.labels)
+		}
+	}
+}
+
+func TestNiceStep(t *testing.T) {
+	for _, test := range []struct{ rough, want float64 }{
+		{rough: 1, want: 1},
+		{rough: 1.4, want: 1},
+		{rough: 1.5, want: 2},
+		{rough: 3, want: 2},
+		{rough: 3.2, want: 5},
+		{rough: 7, want: 5},
+		{rough: 7.1, want: 10},
+		{rough: 2500, want: 2000},
+		{rough: 0.03, want: 0.02},
+		{rough: 0.045, want: 0.05},
+		{rough: 0, want: 0},
+		{rough: -3, want: -3},
+	} {
+		if got := plot.NiceStep(test.rough); got != test.want {
+			t.Errorf("NiceStep(%g) = %g, want %g", test.rough, got, test.want)
+		}
+	}
+}
+
+func TestTickLengthScale(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.HideY()
+	p.X.Min, p.X.Max = 0, 10
+	p.Y.Min, p.Y.Max = 0, 1
+	// A ruler with major, medium and minor gradations,
+	// and a minor tick drawn at the default length.
+	p.X.Tick.Marker = plot.ConstantTicks{
+		{Value: 0, Label: "0"},
+		{Value: 5, LengthScale: 0.75},
+		{Value: 6, LengthScale: 0.25},
+		{Value: 7},
+		{Value: 10, Label: "10", LengthScale: 2},
+	}
+
+	r := recorder.New(72)
+	p.Draw(draw.NewCanvas(r, 200, 100))
+	var got []vg.Length
+	for _, a := range r.Actions {
+		if s, ok := a.(*recorder.Stroke); ok && len(s.Path) == 10 {
+			for i := 0; i < len(s.Path); i += 2 {
+				got = append(got, s.Path[i+1].Y-s.Path[i].Y)
+			}
+		}
+	}
+	want := []vg.Length{8, 6, 2, 4, 8}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected tick lengths: got:%v want:%v", got, want)
+	}
+	for i := range want {
+		if math.Abs(float64(got[i]-want[i])) > 1e-9 {
+			t.Errorf("unexpected tick lengths: got:%v want:%v", got, want)
+			break
+		}
+	}
+}
+
+func TestDefaultTicksMinors(t *testing.T) {
+	for _, test := range []struct {
+		minors int
+		want   int
+	}{
+		{minors: -1, want: 0},
+		{minors: 1, want: 3},
+		{minors: 4, want: 12},
+	} {
+		// The majors in [0, 15] are 0, 5, 10 and 15.
+		ticks := plot.DefaultTicks{Minors: test.minors}.Ticks(0, 15)
+		var majors, minors []float64
+		for _, tk := range ticks {
+			if tk.IsMinor() {
+				minors = append(minors, tk.Value)
+			} else {
+				majors = append(majors, tk.Value)
+			}
+		}
+		if !reflect.DeepEqual(majors, []float64{0, 5, 10, 15}) {
+			t.Errorf("unexpected majors for Minors=%d: %v", test.minors, majors)
+		}
+		if len(minors) != test.want {
+			t.Errorf("unexpected number of minors for Minors=%d: got:%d want:%d", test.minors, len(minors), test.want)
+		}
+	}
+}
+
+func TestTickLabelAlignDecimal(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.HideX()
+	p.X.Min, p.X.Max = 0, 1
+	p.Y.Min, p.Y.Max = 0, 100
+	p.Y.Tick.Marker = plot.ConstantTicks{
+		{Value: 0, Label: "0.25"},
+		{Value: 50, Label: "25.5"},
+		{Value: 100, Label: "100"},
+	}
+	p.Y.Tick.AlignDecimal = true
+
+	r := recorder.New(72)
+	p.Draw(draw.NewCanvas(r, 200, 200))
+	points := make(map[string]vg.Length)
+	for _, a := range r.Actions {
+		if s, ok := a.(*recorder.FillString); ok {
+			// The decimal point, or the end of a label
+			// without one, is after its whole part.
+			whole := s.String
+			if i := strings.Index(whole, "."); i >= 0 {
+				whole = whole[:i]
+			}
+			points[s.String] = s.X + p.Y.Tick.Label.Width(whole)
+		}
+	}
+	if len(points) != 3 {
+		t.Fatalf("unexpected labels drawn: %v", points)
+	}
+	for l, x := range points {
+		if math.Abs(float64(x-points["100"])) > 1e-9 {
+			t.Errorf("decimal point of %q at %v, want %v", l, x, points["100"])
+		}
+	}
+}
+
+func TestJoinAxes(t *testing.T) {
+	var (
+		xColor = color.RGBA{R: 255, A: 255}
+		yColor = color.RGBA{B: 255, A: 255}
+	)
+	for _, join := range []bool{false, true} {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("failed to create plot: %v", err)
+		}
+		p.X.Min, p.X.Max = 0, 1
+		p.Y.Min, p.Y.Max = 0, 1
+		p.X.Color, p.X.Width = xColor, 6
+		p.Y.Color, p.Y.Width = yColor, 4
+		p.JoinAxes = join
+
+		r := recorder.New(72)
+		p.Draw(draw.NewCanvas(r, 200, 200))
+		var cur color.Color
+		var xLine, yLine vg.Path
+		for _, a := range r.Actions {
+			switch a := a.(type) {
+			case *recorder.SetColor:
+				cur = a.Color
+			case *recorder.Stroke:
+				switch cur {
+				case xColor:
+					xLine = a.Path
+				case yColor:
+					yLine = a.Path
+				}
+			}
+		}
+		if len(xLine) == 0 || len(yLine) == 0 {
+			t.Fatalf("unexpected axis lines for JoinAxes=%t: got X:%v Y:%v", join, xLine, yLine)
+		}
+
+		// The outer edges of the lines.
+		left := yLine[0].X - p.Y.Width/2
+		bottom := xLine[0].Y - p.X.Width/2
+		if !join {
+			if xLine[0].X <= left || yLine[0].Y <= bottom {
+				t.Errorf("axis lines unexpectedly meet without JoinAxes: X line starts at %v, Y line starts at %v", xLine[0].X, yLine[0].Y)
+			}
+			continue
+		}
+		if xLine[0].X != left {
+			t.Errorf("unexpected start of X axis line: got:%v want:%v", xLine[0].X, left)
+		}
+		if yLine[0].Y != bottom {
+			t.Errorf("unexpected start of Y axis line: got:%v want:%v", yLine[0].Y, bottom)
+		}
+	}
+
+	// Joining the axes does not move the
+	// break symbol of a broken X axis.
+	var lines [2]vg.Path
+	for i, join := range []bool{false, true} {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("failed to create plot: %v", err)
+		}
+		s := plot.NewBrokenScale(plot.AxisBreak{Min: 10, Max: 1000})
+		p.X.Min, p.X.Max = 0, 1010
+		p.Y.Min, p.Y.Max = 0, 1
+		p.X.Scale, p.X.Tick.Marker = s, s
+		p.X.Color = xColor
+		p.JoinAxes = join
+
+		r := recorder.New(72)
+		p.Draw(draw.NewCanvas(r, 200, 200))
+		var cur color.Color
+		for _, a := range r.Actions {
+			switch a := a.(type) {
+			case *recorder.SetColor:
+				cur = a.Color
+			case *recorder.Stroke:
+				if cur == xColor {
+					lines[i] = a.Path
+				}
+			}
+		}
+	}
+	plain, joined := lines[0], lines[1]
+	if len(joined) != len(plain)+2 || !reflect.DeepEqual(joined[2:], plain) {
+		t.Errorf("unexpected broken X axis line with JoinAxes:\ngot: %v\nwant: the join followed by %v", joined, plain)
+	}
+}
+
+func TestDefaultTicksExpThreshold(t *testing.T) {
+	labels := func(ticks []plot.Tick) []string {
+		var l []string
+		for _, t := range ticks {
+			if t.Label != "" {
+				l = append(l, t.Label)
+			}
+		}
+		return l
+	}
+	for _, test := range []struct {
+		min, max  float64
+		threshold float64
+		want      []string
+	}{
+		{min: 0, max: 2e6, want: []string{"0", "500000", "1e+06", "1.5e+06", "2e+06"}},
+		{min: 0, max: 2e6, threshold: 1e6, want: []string{"0e+00", "5e+05", "1e+06", "1.5e+06", "2e+06"}},
+		{min: 0, max: 2e6, threshold: 1e7, want: []string{"0", "500000", "1000000", "1500000", "2000000"}},
+		{min: 0, max: 2e-5, threshold: 1e4, want: []string{"0e+00", "5e-06", "1e-05", "1.5e-05", "2e-05"}},
+		{min: 0, max: 0.2, threshold: 1e4, want: []string{"0", "0.05", "0.1", "0.15", "0.2"}},
+	} {
+		got := labels(plot.DefaultTicks{ExpThreshold: test.threshold}.Ticks(test.min, test.max))
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected labels for [%g,%g] with threshold %g: got:%q want:%q",
+				test.min, test.max, test.threshold, got, test.want)
+		}
+	}
+
+	ticks := plot.DefaultTicks{ExpThreshold: 1e6, IncludeEnds: true}.Ticks(0, 2.2e6)
+	if end := ticks[len(ticks)-1]; end.Label != "2.2e+06" {
+		t.Errorf("unexpected end label: got:%q want:%q", end.Label, "2.2e+06")
+	}
+}
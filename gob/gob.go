@@ -13,30 +13,47 @@ func init() {
 	gob.Register(color.Gray16{})
 
 	// plot.Ticker
+	gob.Register(plot.CategoryAxis{})
 	gob.Register(plot.ConstantTicks{})
+	gob.Register(plot.DataTicks{})
+	gob.Register(plot.NoTicks{})
+	gob.Register(plot.AllTicks{})
 	gob.Register(plot.DefaultTicks{})
+	gob.Register(plot.ExponentTicks{})
 	gob.Register(plot.LogTicks{})
+	gob.Register(plot.PrecisionTicks{})
+	gob.Register(plot.StepTicks{})
 
 	// plot.Normalizer
 	gob.Register(plot.LinearScale{})
 	gob.Register(plot.LogScale{})
+	gob.Register(plot.BrokenScale{})
 
 	// plot.Plotter
 	gob.Register(plotter.BarChart{})
+	gob.Register(plotter.BarGroup{})
 	gob.Register(plotter.Histogram{})
 	gob.Register(plotter.BoxPlot{})
 	gob.Register(plotter.HorizBoxPlot{})
 	gob.Register(plotter.Bubbles{})
+	gob.Register(plotter.ColorBar{})
+	gob.Register(plotter.ConfidenceEllipse{})
+	gob.Register(plotter.ECDF{})
 	gob.Register(plotter.YErrorBars{})
 	gob.Register(plotter.XErrorBars{})
 	gob.Register(plotter.Function{})
+	gob.Register(plotter.Gauge{})
 	gob.Register(plotter.GlyphBoxes{})
 	gob.Register(plotter.Grid{})
 	gob.Register(plotter.Labels{})
 	gob.Register(plotter.Line{})
+	gob.Register(plotter.LinePoints{})
 	gob.Register(plotter.QuartPlot{})
 	gob.Register(plotter.HorizQuartPlot{})
+	gob.Register(plotter.Rug{})
 	gob.Register(plotter.Scatter{})
+	gob.Register(plotter.StackedArea{})
+	gob.Register(plotter.Violin{})
 
 	// plotter.XYZer
 	gob.Register(plotter.XYZs{})
@@ -67,6 +67,26 @@ type Thumbnailer interface {
 	Thumbnail(c *draw.Canvas)
 }
 
+// LegendNamer wraps the LegendName method.  It
+// should be implemented by Plotters that can be
+// given a name for use in an automatically built
+// legend.
+type LegendNamer interface {
+	// LegendName returns the name of the legend
+	// entry for the Plotter.  If the name is the
+	// empty string then no entry is added.
+	LegendName() string
+}
+
+// NewLegend returns a legend with the default parameter
+// settings, the same as those of the legend of a plot
+// returned by New.  A legend made by NewLegend may be
+// drawn on its own using its Draw method, for example
+// to describe several plots at once with AddPlots.
+func NewLegend() (Legend, error) {
+	return makeLegend()
+}
+
 // makeLegend returns a legend with the default
 // parameter settings.
 func makeLegend() (Legend, error) {
@@ -80,8 +100,11 @@ func makeLegend() (Legend, error) {
 	}, nil
 }
 
-// draw draws the legend to the given draw.Canvas.
-func (l *Legend) draw(c draw.Canvas) {
+// Draw draws the legend to the given draw.Canvas,
+// in the corner given by Top and Left.  A legend may
+// be drawn on its own, outside of any plot, into space
+// reserved for it alongside a set of plots using Size.
+func (l *Legend) Draw(c draw.Canvas) {
 	iconx := c.Min.X
 	textx := iconx + l.ThumbnailWidth + l.TextStyle.Width(" ")
 	xalign := 0.0
@@ -118,6 +141,22 @@ func (l *Legend) draw(c draw.Canvas) {
 	}
 }
 
+// Size returns the width and height of the legend.
+func (l *Legend) Size() (w, h vg.Length) {
+	if len(l.entries) == 0 {
+		return 0, 0
+	}
+	for _, e := range l.entries {
+		if tw := l.TextStyle.Width(e.text); tw > w {
+			w = tw
+		}
+	}
+	w += l.ThumbnailWidth + l.TextStyle.Width(" ")
+	n := vg.Length(len(l.entries))
+	h = n*l.entryHeight() + (n-1)*l.Padding
+	return w, h
+}
+
 // entryHeight returns the height of the tallest legend
 // entry text.
 func (l *Legend) entryHeight() (height vg.Length) {
@@ -135,3 +174,31 @@ func (l *Legend) entryHeight() (height vg.Length) {
 func (l *Legend) Add(name string, thumbs ...Thumbnailer) {
 	l.entries = append(l.entries, legendEntry{text: name, thumbs: thumbs})
 }
+
+// AddPlots adds an entry to the legend for each of the
+// Plotters of the given plots that implements both the
+// LegendNamer and the Thumbnailer interfaces, as
+// AutoLegend does for a single plot.  Only the first
+// Plotter with each name is added, so plots drawing the
+// same series with the same style share an entry.
+// Names already in the legend are not added again.
+func (l *Legend) AddPlots(ps ...*Plot) {
+	seen := make(map[string]bool)
+	for _, e := range l.entries {
+		seen[e.text] = true
+	}
+	for _, p := range ps {
+		for _, d := range p.plotters {
+			n, ok := d.(LegendNamer)
+			if !ok || n.LegendName() == "" || seen[n.LegendName()] {
+				continue
+			}
+			t, ok := d.(Thumbnailer)
+			if !ok {
+				continue
+			}
+			seen[n.LegendName()] = true
+			l.Add(n.LegendName(), t)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package palette
+
+import (
+	"image/color"
+	"math"
+)
+
+// LerpColor returns the color that is the linear interpolation
+// between a and b at t, where t=0 gives a and t=1 gives b.
+// Values of t outside [0, 1] are clamped to that range.
+// The interpolation is of the alpha-premultiplied components.
+func LerpColor(a, b color.Color, t float64) color.Color {
+	t = math.Max(0, math.Min(1, t))
+	ar, ag, ab, aa := a.RGBA()
+	br, bg, bb, ba := b.RGBA()
+	lerp := func(x, y uint32) uint16 {
+		return uint16(float64(x)*(1-t) + float64(y)*t + 0.5)
+	}
+	return color.RGBA64{
+		R: lerp(ar, br),
+		G: lerp(ag, bg),
+		B: lerp(ab, bb),
+		A: lerp(aa, ba),
+	}
+}
+
+// Grayscale returns the gray color with the luminance
+// of c, using the ITU-R BT.601 luma coefficients.  The
+// alpha of c is retained.
+func Grayscale(c color.Color) color.Color {
+	r, g, b, a := c.RGBA()
+	y := uint16(0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b) + 0.5)
+	return color.RGBA64{R: y, G: y, B: y, A: uint16(a)}
+}
@@ -0,0 +1,54 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package palette
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestLerpColor(t *testing.T) {
+	for _, test := range []struct {
+		a, b color.Color
+		t    float64
+		want color.RGBA64
+	}{
+		{a: color.Black, b: color.White, t: 0, want: color.RGBA64{0, 0, 0, 0xffff}},
+		{a: color.Black, b: color.White, t: 1, want: color.RGBA64{0xffff, 0xffff, 0xffff, 0xffff}},
+		{a: color.Black, b: color.White, t: 0.5, want: color.RGBA64{0x8000, 0x8000, 0x8000, 0xffff}},
+		{a: color.Black, b: color.White, t: -1, want: color.RGBA64{0, 0, 0, 0xffff}},
+		{a: color.Black, b: color.White, t: 2, want: color.RGBA64{0xffff, 0xffff, 0xffff, 0xffff}},
+		{
+			a:    color.RGBA{R: 0xff, A: 0xff},
+			b:    color.RGBA{B: 0xff, A: 0xff},
+			t:    0.25,
+			want: color.RGBA64{R: 0xbfff, B: 0x4000, A: 0xffff},
+		},
+		{a: color.Transparent, b: color.White, t: 0.5, want: color.RGBA64{0x8000, 0x8000, 0x8000, 0x8000}},
+	} {
+		if got := LerpColor(test.a, test.b, test.t); got != test.want {
+			t.Errorf("unexpected interpolation of %v and %v at %v: got:%v want:%v",
+				test.a, test.b, test.t, got, test.want)
+		}
+	}
+}
+
+func TestGrayscale(t *testing.T) {
+	for _, test := range []struct {
+		c    color.Color
+		want color.RGBA64
+	}{
+		{c: color.White, want: color.RGBA64{0xffff, 0xffff, 0xffff, 0xffff}},
+		{c: color.Black, want: color.RGBA64{0, 0, 0, 0xffff}},
+		{c: color.RGBA{R: 0xff, A: 0xff}, want: color.RGBA64{0x4c8b, 0x4c8b, 0x4c8b, 0xffff}},
+		{c: color.RGBA{G: 0xff, A: 0xff}, want: color.RGBA64{0x9645, 0x9645, 0x9645, 0xffff}},
+		{c: color.RGBA{B: 0xff, A: 0xff}, want: color.RGBA64{0x1d2f, 0x1d2f, 0x1d2f, 0xffff}},
+		{c: color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0x80}, want: color.RGBA64{0x8080, 0x8080, 0x8080, 0x8080}},
+	} {
+		if got := Grayscale(test.c); got != test.want {
+			t.Errorf("unexpected grayscale of %v: got:%v want:%v", test.c, got, test.want)
+		}
+	}
+}
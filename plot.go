@@ -17,11 +17,17 @@ package plot
 
 import (
 	"fmt"
+	"image"
 	"image/color"
+	"image/color/palette"
+	imgdraw "image/draw"
+	"image/gif"
 	"io"
 	"math"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/gonum/plot/vg"
@@ -35,6 +41,24 @@ import (
 var (
 	// DefaultFont is the name of the default font for plot text.
 	DefaultFont = "Times-Roman"
+
+	// DefaultPadding is the default Padding between
+	// an axis line and the data.  This and the other
+	// layout defaults below are read by New when it
+	// makes the axes, so changing them affects the
+	// plots that are made afterwards.
+	DefaultPadding = vg.Points(5)
+
+	// DefaultTickLength and DefaultMinorTickLength are
+	// the default lengths of major and minor tick marks.
+	// The zero DefaultMinorTickLength makes minor tick
+	// marks half of the length of the major tick marks.
+	DefaultTickLength      = vg.Points(8)
+	DefaultMinorTickLength = vg.Length(0)
+
+	// DefaultLineWidth is the default width of axis
+	// lines and tick marks.
+	DefaultLineWidth = vg.Points(0.5)
 )
 
 // Plot is the basic type representing a plot.
@@ -53,17 +77,106 @@ type Plot struct {
 		draw.TextStyle
 	}
 
+	// Footer is small text drawn in a corner of the
+	// plot, over everything else, for example to give
+	// the source of the data.  The footer does not take
+	// space from the rest of the plot.
+	Footer struct {
+		// Text is the text of the footer.  If Text
+		// is the empty string then no footer is drawn.
+		Text string
+
+		// Top and Left specify the corner in which
+		// the footer is drawn.  The default is the
+		// bottom right corner.
+		Top, Left bool
+
+		// Padding is the distance from the
+		// edges of the plot to the footer.
+		Padding vg.Length
+
+		draw.TextStyle
+	}
+
 	// BackgroundColor is the background color of the plot.
 	// The default is White.
 	BackgroundColor color.Color
 
+	// ForegroundColor, if not nil, is the color of the
+	// text, the axis lines and the tick marks of the plot
+	// whose color is still the default black given by New.
+	// The plotters and the legend thumbnails are drawn
+	// with ForegroundColor in place of black too, so
+	// that the default black styles of the plotters
+	// follow it.  Elements with another color set
+	// individually are left unchanged.  See Theme.
+	ForegroundColor color.Color
+
 	// X and Y are the horizontal and vertical axes
 	// of the plot respectively.
 	X, Y Axis
 
+	// TopX, if not nil, is a secondary horizontal axis
+	// drawn along the top of the data area, for example
+	// to label frequencies over wavelengths.  Its range
+	// is independent of that of X and is not changed by
+	// Add.  Plotters are always drawn using X, so TopX is
+	// only a reference scale.  See AddTopX.
+	TopX *Axis
+
 	// Legend is the plot's legend.
 	Legend Legend
 
+	// HideOriginLabel specifies that when both
+	// axes start at zero, so that their "0" tick labels
+	// meet at the corner of the plot, the label of the
+	// Y axis at zero is not drawn.
+	HideOriginLabel bool
+
+	// NoGlyphPadding specifies that the data area is
+	// not inset to fit the glyphs of the plotters.  By
+	// default the data area is inset so that glyphs at
+	// its edges, such as a scatter point at the maximum
+	// of an axis, are drawn in full within the plot.
+	// With NoGlyphPadding the data area extends to the
+	// axes and such glyphs may overlap them or be cut
+	// off at the edge of the canvas.
+	NoGlyphPadding bool
+
+	// JoinAxes specifies that the lines of the X and
+	// the Y axis are extended across their padding and
+	// the glyph padding of the data area to meet at the
+	// lower left corner of the plot.  Each line ends at
+	// the outer edge of the other, so that the corner is
+	// square whatever the widths of the lines.
+	JoinAxes bool
+
+	// Font specifies a font for all of the text of
+	// the plot whose font is still the default given
+	// by New.  Text with a font set individually is
+	// left unchanged.
+	Font struct {
+		// Name is the name of the font.  If Name
+		// is the empty string then the font face
+		// is not changed.
+		Name string
+
+		// Size is the size of the title, the axis
+		// labels and the legend.  Tick labels are
+		// scaled in proportion to their default size.
+		// If Size is zero then sizes are not changed.
+		Size vg.Length
+
+		// ScaleLengths specifies whether the axis
+		// padding, the tick lengths and the legend
+		// thumbnail width are also scaled in proportion
+		// to Size, keeping the proportions of the plot
+		// when the font is enlarged.  As with fonts,
+		// only lengths that are still the default given
+		// by New are scaled.
+		ScaleLengths bool
+	}
+
 	// plotters are drawn by calling their Plot method
 	// after the axes are drawn.
 	plotters []Plotter
@@ -85,6 +198,15 @@ type DataRanger interface {
 	DataRange() (xmin, xmax, ymin, ymax float64)
 }
 
+// DataValuer wraps the DataValues method.  Plotters
+// that implement DataValuer are ranged by the values
+// of their data in PercentileRange.
+type DataValuer interface {
+	// DataValues returns the X and Y values
+	// of the data.
+	DataValues() (xs, ys []float64)
+}
+
 // New returns a new plot with some reasonable
 // default settings.
 func New() (*Plot, error) {
@@ -104,6 +226,7 @@ func New() (*Plot, error) {
 	if err != nil {
 		return nil, err
 	}
+	y.Tick.LabelXAlign, y.Tick.LabelYAlign = -1, -0.5
 	p := &Plot{
 		BackgroundColor: color.White,
 		X:               x,
@@ -114,6 +237,15 @@ func New() (*Plot, error) {
 		Color: color.Black,
 		Font:  titleFont,
 	}
+	footerFont, err := vg.MakeFont(DefaultFont, 8)
+	if err != nil {
+		return nil, err
+	}
+	p.Footer.Padding = vg.Points(2)
+	p.Footer.TextStyle = draw.TextStyle{
+		Color: color.Black,
+		Font:  footerFont,
+	}
 	return p, nil
 }
 
@@ -140,6 +272,120 @@ func (p *Plot) Add(ps ...Plotter) {
 	p.plotters = append(p.plotters, ps...)
 }
 
+// PercentileRange sets the ranges of the X and Y axes
+// to span from the lo to the hi percentile of the data
+// values, so that a few extreme outliers do not flatten
+// the rest of the data.  The percentiles are between 0
+// and 100, for example 1 and 99.  Plotters that do not
+// implement DataValuer contribute their DataRange, if
+// any, in full.  Data beyond the new ranges are clipped
+// or clamped as each plotter draws data outside the
+// data area, see for example plotter.Scatter.
+//
+// Plotters added after PercentileRange is called
+// extend the ranges to fit their data as usual.
+func (p *Plot) PercentileRange(lo, hi float64) {
+	xmin, xmax := math.Inf(1), math.Inf(-1)
+	ymin, ymax := math.Inf(1), math.Inf(-1)
+	var xs, ys []float64
+	for _, d := range p.plotters {
+		if v, ok := d.(DataValuer); ok {
+			dxs, dys := v.DataValues()
+			xs = append(xs, dxs...)
+			ys = append(ys, dys...)
+			continue
+		}
+		if r, ok := d.(DataRanger); ok {
+			dxmin, dxmax, dymin, dymax := r.DataRange()
+			xmin, xmax = math.Min(xmin, dxmin), math.Max(xmax, dxmax)
+			ymin, ymax = math.Min(ymin, dymin), math.Max(ymax, dymax)
+		}
+	}
+	if min, max, ok := percentiles(xs, lo, hi); ok {
+		xmin, xmax = math.Min(xmin, min), math.Max(xmax, max)
+	}
+	if min, max, ok := percentiles(ys, lo, hi); ok {
+		ymin, ymax = math.Min(ymin, min), math.Max(ymax, max)
+	}
+	p.X.Min, p.X.Max = xmin, xmax
+	p.Y.Min, p.Y.Max = ymin, ymax
+}
+
+// percentiles returns the lo and hi percentiles of the
+// values that are not NaN, interpolating linearly between
+// the closest ranks.  It returns false if there are no
+// such values.
+func percentiles(vs []float64, lo, hi float64) (min, max float64, ok bool) {
+	sorted := make([]float64, 0, len(vs))
+	for _, v := range vs {
+		if !math.IsNaN(v) {
+			sorted = append(sorted, v)
+		}
+	}
+	if len(sorted) == 0 {
+		return 0, 0, false
+	}
+	sort.Float64s(sorted)
+	at := func(p float64) float64 {
+		r := math.Max(0, math.Min(1, p/100)) * float64(len(sorted)-1)
+		i := int(r)
+		if i+1 >= len(sorted) {
+			return sorted[len(sorted)-1]
+		}
+		return sorted[i] + (r-float64(i))*(sorted[i+1]-sorted[i])
+	}
+	return at(lo), at(hi), true
+}
+
+// AutoLegend adds an entry to the plot's Legend
+// for each of its Plotters that implements both the
+// LegendNamer and the Thumbnailer interfaces.
+// Plotters with an empty LegendName are skipped.
+//
+// Entries are added in the order in which the
+// Plotters were added to the plot.
+func (p *Plot) AutoLegend() {
+	for _, d := range p.plotters {
+		n, ok := d.(LegendNamer)
+		if !ok || n.LegendName() == "" {
+			continue
+		}
+		t, ok := d.(Thumbnailer)
+		if !ok {
+			continue
+		}
+		p.Legend.Add(n.LegendName(), t)
+	}
+}
+
+// Validate returns an error if the range of either
+// axis of the plot contains a NaN, has only one end set,
+// or is inverted.  The ranges of a plot with no data are
+// not set and are valid.
+// Draw does not report these errors, instead it draws
+// using a reasonable substitute range, so Validate should
+// be called before Draw to detect them.  WriterTo and Save
+// call Validate and return its error.
+func (p *Plot) Validate() error {
+	if err := p.X.validate("X"); err != nil {
+		return err
+	}
+	if err := p.Y.validate("Y"); err != nil {
+		return err
+	}
+	if p.TopX != nil {
+		if err := p.TopX.validate("TopX"); err != nil {
+			return err
+		}
+	}
+	if p.Font.Name != "" {
+		if _, err := vg.MakeFont(p.Font.Name, p.Font.Size); err != nil {
+			return fmt.Errorf("plot: invalid plot font %q: %v", p.Font.Name, err)
+		}
+	}
+	return nil
+}
+
 // Draw draws a plot to a draw.Canvas.
 //
 // Plotters are drawn in the order in which they were
@@ -147,7 +393,45 @@ func (p *Plot) Add(ps ...Plotter) {
 // GlyphBoxer interface will have their GlyphBoxes
 // taken into account when padding the plot so that
 // none of their glyphs are clipped.
+//
+// Axis ranges that are unset or inverted are replaced
+// by a reasonable default; see Validate.  A plot with
+// no data is drawn as just its axes, over the range 0
+// to 1 unless the ranges are set, and DrawWithInfo
+// reports it with a warning.
 func (p *Plot) Draw(c draw.Canvas) {
+	p.DrawWithInfo(c)
+}
+
+// DrawInfo describes the layout of a drawn plot.
+type DrawInfo struct {
+	// DataArea is the rectangle of the canvas
+	// into which the plot data were drawn.
+	DataArea draw.Rectangle
+
+	// Warnings describes any problems found
+	// while laying out the plot, such as an axis
+	// range that had to be replaced or tick
+	// labels that overlap.
+	Warnings []string
+}
+
+// DrawWithInfo draws a plot to a draw.Canvas in the
+// same way as Draw, and returns a description of the
+// resulting layout.
+func (p *Plot) DrawWithInfo(c draw.Canvas) DrawInfo {
+	var info DrawInfo
+	if len(p.plotters) == 0 && (p.X.unset() || p.Y.unset()) {
+		info.Warnings = append(info.Warnings, "plot has no data")
+	}
+	info.Warnings = append(info.Warnings, p.X.rangeWarnings("X")...)
+	info.Warnings = append(info.Warnings, p.Y.rangeWarnings("Y")...)
+
+	p.X.sanitizeRange()
+	p.Y.sanitizeRange()
+	p = p.withFont()
+	full := c
+
 	if p.BackgroundColor != nil {
 		c.SetColor(p.BackgroundColor)
 		c.Fill(c.Rectangle.Path())
@@ -158,37 +442,273 @@ func (p *Plot) Draw(c draw.Canvas) {
 		c.Max.Y -= p.Title.Padding
 	}
 
-	p.X.sanitizeRange()
-	x := horizontalAxis{p.X}
-	p.Y.sanitizeRange()
-	y := verticalAxis{p.Y}
+	x := horizontalAxis{Axis: p.X}
+	y := verticalAxis{Axis: p.Y}
+	y.hideOrigin = p.HideOriginLabel && p.X.Min == 0 && p.Y.Min == 0
+	x.bounds, y.bounds = c.Rectangle, c.Rectangle
 
 	ywidth := y.size()
-	x.draw(padX(p, c.Crop(ywidth, 0, 0, 0)))
+	if top, ok := p.topAxis(); ok {
+		top.bounds = c.Rectangle
+		topc := padX(p, c.Crop(ywidth, 0, 0, 0))
+		top.strokeLine(topc, top.draw(topc))
+		c.Max.Y -= top.size()
+	}
+	xc := padX(p, c.Crop(ywidth, 0, 0, 0))
 	xheight := x.size()
-	y.draw(padY(p, c.Crop(0, xheight, 0, 0)))
+	yc := padY(p, c.Crop(0, xheight, 0, 0))
+	xline := x.draw(xc)
+	if !p.JoinAxes {
+		x.strokeLine(xc, xline)
+	}
+	yline := y.draw(yc)
+	if p.JoinAxes {
+		// The X axis line waits for the location of the
+		// Y axis line, and each is extended to the outer
+		// edge of the other.
+		if x.drawLine() && y.drawLine() {
+			x.join = xc.Min.X - (yline - y.Width/2)
+			y.join = yc.Min.Y - (xline - x.Width/2)
+		}
+		x.strokeLine(xc, xline)
+	}
+	y.strokeLine(yc, yline)
 
-	dataC := padY(p, padX(p, c.Crop(ywidth, xheight, 0, 0)))
+	dataC := p.dataCanvas(c, x, y)
+	drawGrid(dataC, x, y)
+	plotC := p.withForeground(dataC)
 	for _, data := range p.plotters {
-		data.Plot(dataC, p)
+		data.Plot(plotC, p)
 	}
 
-	p.Legend.draw(c.Crop(ywidth, 0, 0, 0).Crop(0, xheight, 0, 0))
+	p.Legend.Draw(p.withForeground(c.Crop(ywidth, 0, 0, 0).Crop(0, xheight, 0, 0)))
+
+	if x.labelsOverlap(dataC) {
+		info.Warnings = append(info.Warnings, "X axis tick labels overlap")
+	}
+	if y.labelsOverlap(dataC) {
+		info.Warnings = append(info.Warnings, "Y axis tick labels overlap")
+	}
+	if sz := dataC.Size(); sz.X <= 0 || sz.Y <= 0 {
+		info.Warnings = append(info.Warnings, "no room for the data area")
+	}
+	p.drawFooter(full)
+	info.DataArea = dataC.Rectangle
+	return info
+}
+
+// drawFooter draws the footer in its corner of the canvas.
+func (p *Plot) drawFooter(c draw.Canvas) {
+	f := &p.Footer
+	if f.Text == "" {
+		return
+	}
+	x, xalign := c.Max.X-f.Padding, -1.0
+	if f.Left {
+		x, xalign = c.Min.X+f.Padding, 0
+	}
+	y, yalign := c.Min.Y+f.Padding-f.Font.Extents().Descent, 0.0
+	if f.Top {
+		y, yalign = c.Max.Y-f.Padding, -1
+	}
+	c.FillText(f.TextStyle, x, y, xalign, yalign, f.Text)
 }
 
 // DataCanvas returns a new draw.Canvas that
 // is the subset of the given draw area into which
-// the plot data will be drawn.
+// the plot data will be drawn.  It is the same
+// canvas that Draw passes to each of the plot's
+// Plotters, so its Rectangle may be used to place
+// overlays or to hit-test points after drawing.
 func (p *Plot) DataCanvas(da draw.Canvas) draw.Canvas {
+	p.X.sanitizeRange()
+	p.Y.sanitizeRange()
+	p = p.withFont()
+
 	if p.Title.Text != "" {
 		da.Max.Y -= p.Title.Height(p.Title.Text) - p.Title.Font.Extents().Descent
 		da.Max.Y -= p.Title.Padding
 	}
-	p.X.sanitizeRange()
-	x := horizontalAxis{p.X}
-	p.Y.sanitizeRange()
-	y := verticalAxis{p.Y}
-	return padY(p, padX(p, da.Crop(y.size(), x.size(), 0, 0)))
+	if top, ok := p.topAxis(); ok {
+		da.Max.Y -= top.size()
+	}
+	x := horizontalAxis{Axis: p.X}
+	y := verticalAxis{Axis: p.Y}
+	return p.dataCanvas(da, x, y)
+}
+
+// DrawGrid draws the grid lines of both the X and
+// the Y axis, given by their GridStyles, across the
+// data area of the draw.Canvas.  Draw calls DrawGrid
+// before drawing the plotters, so it is only needed
+// when drawing a plot's data by some other means.
+func (p *Plot) DrawGrid(c draw.Canvas) {
+	c = p.DataCanvas(c)
+	drawGrid(c, horizontalAxis{Axis: p.X}, verticalAxis{Axis: p.Y})
+}
+
+// drawGrid draws the grid lines of both axes across the
+// data canvas.  If the axes have the same GridStyle then
+// all of the lines are stroked together.
+func drawGrid(c draw.Canvas, x horizontalAxis, y verticalAxis) {
+	xs, ys := x.gridLines(c), y.gridLines(c)
+	if len(xs) > 0 && len(ys) > 0 && reflect.DeepEqual(x.GridStyle, y.GridStyle) {
+		c.StrokeLines(x.GridStyle, append(xs, ys...)...)
+		return
+	}
+	c.StrokeLines(x.GridStyle, xs...)
+	c.StrokeLines(y.GridStyle, ys...)
+}
+
+// withFont returns the plot with p.Font and p.ForegroundColor
+// applied to the styles that have the default font or color.
+// If neither is set then p is returned, otherwise the returned
+// plot is a copy of p.
+func (p *Plot) withFont() *Plot {
+	setFont := p.Font.Name != "" || p.Font.Size != 0
+	if !setFont && p.ForegroundColor == nil {
+		return p
+	}
+	q := *p
+	top := &Axis{}
+	if p.TopX != nil {
+		t := *p.TopX
+		q.TopX, top = &t, &t
+	}
+	for _, t := range []struct {
+		sty  *draw.TextStyle
+		size vg.Length
+	}{
+		{&q.Title.TextStyle, 12},
+		{&q.X.Label.TextStyle, 12},
+		{&q.Y.Label.TextStyle, 12},
+		{&q.X.Tick.Label, 10},
+		{&q.Y.Tick.Label, 10},
+		{&top.Label.TextStyle, 12},
+		{&top.Tick.Label, 10},
+		{&q.Legend.TextStyle, 12},
+		{&q.Footer.TextStyle, 8},
+	} {
+		if p.ForegroundColor != nil && t.sty.Color == color.Black {
+			t.sty.Color = p.ForegroundColor
+		}
+		f := t.sty.Font
+		if !setFont || f.Name() != DefaultFont || f.Size != t.size {
+			continue
+		}
+		name, size := p.Font.Name, f.Size
+		if name == "" {
+			name = f.Name()
+		}
+		if p.Font.Size != 0 {
+			size = p.Font.Size * t.size / 12
+		}
+		if f, err := vg.MakeFont(name, size); err == nil {
+			t.sty.Font = f
+		}
+	}
+	if p.ForegroundColor != nil {
+		for _, l := range []*draw.LineStyle{
+			&q.X.LineStyle, &q.Y.LineStyle, &top.LineStyle,
+			&q.X.Tick.LineStyle, &q.Y.Tick.LineStyle, &top.Tick.LineStyle,
+		} {
+			if l.Color == color.Black {
+				l.Color = p.ForegroundColor
+			}
+		}
+	}
+	if p.Font.ScaleLengths && p.Font.Size != 0 {
+		for _, l := range []struct {
+			len *vg.Length
+			def vg.Length
+		}{
+			{&q.X.Padding, q.X.defaults.padding},
+			{&q.Y.Padding, q.Y.defaults.padding},
+			{&q.X.Tick.Length, q.X.defaults.tick},
+			{&q.Y.Tick.Length, q.Y.defaults.tick},
+			{&q.X.Tick.MinorLength, q.X.defaults.minorTick},
+			{&q.Y.Tick.MinorLength, q.Y.defaults.minorTick},
+			{&top.Padding, top.defaults.padding},
+			{&top.Tick.Length, top.defaults.tick},
+			{&top.Tick.MinorLength, top.defaults.minorTick},
+			{&q.Legend.ThumbnailWidth, 20},
+		} {
+			if *l.len == l.def {
+				*l.len = p.Font.Size * l.def / 12
+			}
+		}
+	}
+	return &q
+}
+
+// withForeground returns the canvas drawing with
+// p.ForegroundColor in place of black.  If the
+// ForegroundColor is not set then c is returned.
+func (p *Plot) withForeground(c draw.Canvas) draw.Canvas {
+	if p.ForegroundColor == nil {
+		return c
+	}
+	fc := foregroundCanvas{Canvas: c.Canvas, fg: p.ForegroundColor}
+	if cl, ok := c.Canvas.(vg.Clipper); ok {
+		c.Canvas = clipForegroundCanvas{foregroundCanvas: fc, Clipper: cl}
+	} else {
+		c.Canvas = fc
+	}
+	return c
+}
+
+// foregroundCanvas is a vg.Canvas that sets
+// the color fg whenever black is set.
+type foregroundCanvas struct {
+	vg.Canvas
+	fg color.Color
+}
+
+func (c foregroundCanvas) SetColor(clr color.Color) {
+	// A nil color is black, see vg.Canvas.
+	if clr == nil || clr == color.Black {
+		clr = c.fg
+	}
+	c.Canvas.SetColor(clr)
+}
+
+// clipForegroundCanvas is a foregroundCanvas
+// of a vg.Canvas that implements vg.Clipper.
+type clipForegroundCanvas struct {
+	foregroundCanvas
+	vg.Clipper
+}
+
+// topAxis returns the plot's TopX, with its range
+// sanitized, as an axis drawn along the top of the
+// plot.  It returns false if the plot has no TopX.
+func (p *Plot) topAxis() (horizontalAxis, bool) {
+	if p.TopX == nil {
+		return horizontalAxis{}, false
+	}
+	a := horizontalAxis{Axis: *p.TopX, top: true}
+	a.sanitizeRange()
+	return a, true
+}
+
+// dataCanvas returns the subset of the draw area,
+// with the title already removed, into which the
+// plot data is drawn given the plot's axes.
+func (p *Plot) dataCanvas(da draw.Canvas, x horizontalAxis, y verticalAxis) draw.Canvas {
+	c := padY(p, padX(p, da.Crop(y.size(), x.size(), 0, 0)))
+
+	// Large glyphs or axes on a small canvas can leave
+	// no room for the data; collapse the data area rather
+	// than inverting it.
+	if c.Min.X > c.Max.X {
+		c.Min.X = (c.Min.X + c.Max.X) / 2
+		c.Max.X = c.Min.X
+	}
+	if c.Min.Y > c.Max.Y {
+		c.Min.Y = (c.Min.Y + c.Max.Y) / 2
+		c.Max.Y = c.Min.Y
+	}
+	return c
 }
 
 // DrawGlyphBoxes draws red outlines around the plot's
@@ -205,9 +725,9 @@ func (p *Plot) DrawGlyphBoxes(c *draw.Canvas) {
 // padX returns a draw.Canvas that is padded horizontally
 // so that glyphs will no be clipped.
 func padX(p *Plot, c draw.Canvas) draw.Canvas {
-	glyphs := p.GlyphBoxes(p)
+	glyphs := p.paddedGlyphBoxes()
 	l := leftMost(&c, glyphs)
-	xAxis := horizontalAxis{p.X}
+	xAxis := horizontalAxis{Axis: p.X}
 	glyphs = append(glyphs, xAxis.GlyphBoxes(p)...)
 	r := rightMost(&c, glyphs)
 
@@ -261,9 +781,9 @@ func leftMost(c *draw.Canvas, boxes []GlyphBox) GlyphBox {
 // padY returns a draw.Canvas that is padded vertically
 // so that glyphs will no be clipped.
 func padY(p *Plot, c draw.Canvas) draw.Canvas {
-	glyphs := p.GlyphBoxes(p)
+	glyphs := p.paddedGlyphBoxes()
 	b := bottomMost(&c, glyphs)
-	yAxis := verticalAxis{p.Y}
+	yAxis := verticalAxis{Axis: p.Y}
 	glyphs = append(glyphs, yAxis.GlyphBoxes(p)...)
 	t := topMost(&c, glyphs)
 
@@ -324,6 +844,23 @@ func (p *Plot) Transforms(c *draw.Canvas) (x, y func(float64) vg.Length) {
 	return
 }
 
+// TransformXs returns the values of xs, given in the data
+// coordinate system of the X axis, transformed to the draw
+// coordinate system of the given draw area.  The result is
+// the same as that of the x function returned by Transforms
+// applied to each value, up to rounding, however the scaling
+// is computed once for the whole slice.
+func (p *Plot) TransformXs(c *draw.Canvas, xs []float64) []vg.Length {
+	return p.X.norms(xs, c.Min.X, c.Max.X)
+}
+
+// TransformYs is like TransformXs for values
+// given in the data coordinate system of the
+// Y axis.
+func (p *Plot) TransformYs(c *draw.Canvas, ys []float64) []vg.Length {
+	return p.Y.norms(ys, c.Min.Y, c.Max.Y)
+}
+
 // GlyphBoxer wraps the GlyphBoxes method.
 // It should be implemented by things that meet
 // the Plotter interface that draw glyphs so that
@@ -387,6 +924,15 @@ func (p *Plot) GlyphBoxes(*Plot) (boxes []GlyphBox) {
 	return
 }
 
+// paddedGlyphBoxes returns the GlyphBoxes of the
+// plotters that the data area is padded to fit.
+func (p *Plot) paddedGlyphBoxes() []GlyphBox {
+	if p.NoGlyphPadding {
+		return nil
+	}
+	return p.GlyphBoxes(p)
+}
+
 // NominalX configures the plot to have a nominal X
 // axis—an X axis with names instead of numbers.  The
 // X location corresponding to each name are the integers,
@@ -397,18 +943,35 @@ func (p *Plot) GlyphBoxes(*Plot) (boxes []GlyphBox) {
 func (p *Plot) NominalX(names ...string) {
 	p.X.Tick.Width = 0
 	p.X.Tick.Length = 0
+	p.X.Tick.MinorLength = 0
 	p.X.Width = 0
 	p.Y.Padding = p.X.Tick.Label.Width(names[0]) / 2
 	ticks := make([]Tick, len(names))
 	for i, name := range names {
-		ticks[i] = Tick{float64(i), name}
+		ticks[i] = Tick{Value: float64(i), Label: name}
 	}
 	p.X.Tick.Marker = ConstantTicks(ticks)
 }
 
+// CategoryX configures the plot to have a categorical X
+// axis with the given category names, as for NominalX, and
+// returns the CategoryAxis used as the axis's Tick.Marker so
+// that plotters may be placed at the values of the categories
+// by its Index method.  The range of the axis is extended to
+// give each category a slot one unit wide.
+func (p *Plot) CategoryX(names ...string) CategoryAxis {
+	p.NominalX(names...)
+	c := CategoryAxis(names)
+	p.X.Tick.Marker = c
+	p.X.Min = math.Min(p.X.Min, -0.5)
+	p.X.Max = math.Max(p.X.Max, float64(len(names))-0.5)
+	return c
+}
+
 // HideX configures the X axis so that it will not be drawn.
 func (p *Plot) HideX() {
 	p.X.Tick.Length = 0
+	p.X.Tick.MinorLength = 0
 	p.X.Width = 0
 	p.X.Tick.Marker = ConstantTicks([]Tick{})
 }
@@ -416,6 +979,7 @@ func (p *Plot) HideX() {
 // HideY configures the Y axis so that it will not be drawn.
 func (p *Plot) HideY() {
 	p.Y.Tick.Length = 0
+	p.Y.Tick.MinorLength = 0
 	p.Y.Width = 0
 	p.Y.Tick.Marker = ConstantTicks([]Tick{})
 }
@@ -426,26 +990,214 @@ func (p *Plot) HideAxes() {
 	p.HideY()
 }
 
+// AddTopX sets the plot's TopX to a new axis with the
+// default settings and the range min to max, and returns
+// it so that it may be customized further.
+func (p *Plot) AddTopX(min, max float64) (*Axis, error) {
+	a, err := makeAxis()
+	if err != nil {
+		return nil, err
+	}
+	a.Min, a.Max = min, max
+	p.TopX = &a
+	return p.TopX, nil
+}
+
 // NominalY is like NominalX, but for the Y axis.
 func (p *Plot) NominalY(names ...string) {
 	p.Y.Tick.Width = 0
 	p.Y.Tick.Length = 0
+	p.Y.Tick.MinorLength = 0
 	p.Y.Width = 0
 	p.X.Padding = p.Y.Tick.Label.Height(names[0]) / 2
 	ticks := make([]Tick, len(names))
 	for i, name := range names {
-		ticks[i] = Tick{float64(i), name}
+		ticks[i] = Tick{Value: float64(i), Label: name}
 	}
 	p.Y.Tick.Marker = ConstantTicks(ticks)
 }
 
+// CategoryY is like CategoryX, but for the Y axis.
+func (p *Plot) CategoryY(names ...string) CategoryAxis {
+	p.NominalY(names...)
+	c := CategoryAxis(names)
+	p.Y.Tick.Marker = c
+	p.Y.Min = math.Min(p.Y.Min, -0.5)
+	p.Y.Max = math.Max(p.Y.Max, float64(len(names))-0.5)
+	return c
+}
+
+// SuggestSize returns a width and height for an image of
+// the plot, suitable for passing to Save or WriterTo, that
+// give the data area an aspect ratio following that of the
+// ranges of the axes, limited to between 1:2 and 2:1, and
+// make the image large enough to be readable when rendered
+// at the given DPI.  The space needed by the title and the
+// axes is added to that of the data area.  The ranges of
+// LogScale axes are measured in decades.
+func (p *Plot) SuggestSize(dpi int) (w, h vg.Length) {
+	const (
+		// dataSize is the length of the longer
+		// side of the data area.
+		dataSize = 4 * vg.Inch
+
+		// minDots is the least number of dots
+		// in either dimension of the image.
+		minDots = 300
+	)
+
+	x, y := p.X, p.Y
+	x.sanitizeRange()
+	y.sanitizeRange()
+	aspect := x.extent() / y.extent()
+	if math.IsNaN(aspect) {
+		aspect = 1
+	}
+	aspect = math.Max(0.5, math.Min(2, aspect))
+
+	dw, dh := dataSize, dataSize
+	if aspect > 1 {
+		dh /= vg.Length(aspect)
+	} else {
+		dw *= vg.Length(aspect)
+	}
+	// ew and eh are the space taken by the title,
+	// the axes and the padding around the data area.
+	c := p.DataCanvas(draw.Canvas{Rectangle: draw.Rectangle{Max: draw.Point{dw, dh}}})
+	sz := c.Size()
+	ew, eh := dw-sz.X, dh-sz.Y
+
+	if dpi > 0 {
+		// Grow the data area so that each side of
+		// the image has at least minDots dots.
+		min := vg.Length(minDots / float64(dpi) * vg.Inch.Points())
+		f := math.Max(float64((min-ew)/dw), float64((min-eh)/dh))
+		if f > 1 {
+			dw *= vg.Length(f)
+			dh *= vg.Length(f)
+		}
+	}
+	w, h = dw+ew, dh+eh
+	return w, h
+}
+
+// MinSize returns the smallest width and height of an image
+// of the plot in which no tick labels overlap, the axis labels
+// fit along their axes, and the title and the legend are not
+// cropped.  It accounts for the current fonts, the tick labels
+// of the current axis ranges, and the space taken by the title
+// and the axes.  Glyphs of the plotted data are not considered.
+func (p *Plot) MinSize() (w, h vg.Length) {
+	q := *p
+	q.X.sanitizeRange()
+	q.Y.sanitizeRange()
+	p = q.withFont()
+	x := horizontalAxis{Axis: p.X}
+	y := verticalAxis{Axis: p.Y}
+
+	dw, overw := p.X.minLength(p.X.Tick.Label.Width, p.X.Tick.Label.Width(" "))
+	if lw := p.X.Label.Width(p.X.labelText()); lw > dw {
+		dw = lw
+	}
+	dh, overh := p.Y.minLength(p.Y.Tick.Label.Height, 0)
+	if lw := p.Y.Label.Width(p.Y.labelText()); lw > dh {
+		dh = lw
+	}
+
+	// The legend is drawn over the data area.
+	lw, lh := p.Legend.Size()
+	w = y.size() + vg.Length(math.Max(float64(dw+overw), float64(lw)))
+	h = x.size() + vg.Length(math.Max(float64(dh+overh), float64(lh)))
+	if top, ok := p.topAxis(); ok {
+		h += top.size()
+	}
+
+	if p.Title.Text != "" {
+		if tw := p.Title.Width(p.Title.Text); tw > w {
+			w = tw
+		}
+		h += p.Title.Height(p.Title.Text) - p.Title.Font.Extents().Descent
+		h += p.Title.Padding
+	}
+	return w, h
+}
+
+// RenderToImage returns the plot drawn to a raster image of
+// the given size at the given number of dots per inch, as it
+// would be saved in the png format.  RenderToImage is useful
+// for comparing the drawing of a plot against a golden image
+// in tests.  An error is returned if the plot is not valid,
+// see Validate.
+func RenderToImage(p *Plot, w, h vg.Length, dpi int) (image.Image, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	if dpi <= 0 {
+		return nil, fmt.Errorf("plot: invalid dpi: %d", dpi)
+	}
+	c := vgimg.NewWithDPI(w, h, dpi)
+	p.Draw(draw.New(c))
+	return c.Image(), nil
+}
+
+// SaveGIF saves the plots as the frames of an animated GIF file
+// of the given size, with each frame shown for delay hundredths
+// of a second.  Each frame is rendered as a raster image at the
+// given number of dots per inch, as for the png format, and is
+// then reduced to a 256 color palette.
+//
+// Each plot computes its own axis ranges, so the axes of the
+// frames will jump about unless the caller sets the same Min
+// and Max for the X and Y axes of every frame.
+func SaveGIF(frames []*Plot, w, h vg.Length, dpi, delay int, file string) (err error) {
+	if len(frames) == 0 {
+		return fmt.Errorf("plot: no frames to save")
+	}
+	anim := &gif.GIF{
+		Image: make([]*image.Paletted, len(frames)),
+		Delay: make([]int, len(frames)),
+	}
+	// The frames are drawn in turn on one canvas,
+	// which is cleared between them.
+	c := vgimg.NewWithDPI(w, h, dpi)
+	for i, p := range frames {
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("plot: frame %d: %v", i, err)
+		}
+		dc := draw.New(c)
+		dc.Clear(color.White)
+		p.Draw(dc)
+		img := c.Image()
+		frame := image.NewPaletted(img.Bounds(), palette.Plan9)
+		imgdraw.Draw(frame, img.Bounds(), img, img.Bounds().Min, imgdraw.Src)
+		anim.Image[i] = frame
+		anim.Delay[i] = delay
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		e := f.Close()
+		if err == nil {
+			err = e
+		}
+	}()
+	return gif.EncodeAll(f, anim)
+}
+
 // WriterTo returns an io.WriterTo that will write the plot as
-// the specified image format.
+// the specified image format.  An error is returned if the
+// plot is not valid, see Validate.
 //
 // Supported formats are:
 //
 //  eps, jpg|jpeg, pdf, png, svg, and tif|tiff.
 func (p *Plot) WriterTo(w, h vg.Length, format string) (io.WriterTo, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
 	var c interface {
 		vg.CanvasSizer
 		io.WriterTo
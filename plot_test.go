@@ -7,7 +7,13 @@ package plot_test
 import (
 	"bytes"
 	"fmt"
+	"image"
 	"image/color"
+	"image/gif"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -16,6 +22,7 @@ import (
 	"github.com/gonum/plot/vg"
 	"github.com/gonum/plot/vg/draw"
 	"github.com/gonum/plot/vg/recorder"
+	"github.com/gonum/plot/vg/vgimg"
 )
 
 func TestLegendAlignment(t *testing.T) {
@@ -204,6 +211,81 @@ func TestLegendAlignment(t *testing.T) {
 	}
 }
 
+func TestAutoLegend(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	xys := plotter.XYs{{0, 0}, {1, 1}}
+	for _, n := range []string{"A", "", "B"} {
+		l, err := plotter.NewLine(xys)
+		if err != nil {
+			t.Fatalf("failed to create line %q: %v", n, err)
+		}
+		l.Name = n
+		p.Add(l)
+	}
+	s, err := plotter.NewScatter(xys)
+	if err != nil {
+		t.Fatalf("failed to create scatter: %v", err)
+	}
+	s.Name = "C"
+	p.Add(s)
+	p.AutoLegend()
+
+	r := recorder.New(100)
+	p.Legend.Draw(draw.NewCanvas(r, 100, 100))
+
+	var got []string
+	for _, a := range r.Actions {
+		if fs, ok := a.(*recorder.FillString); ok {
+			got = append(got, fs.String)
+		}
+	}
+	want := []string{"A", "B", "C"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected legend entries: got:%q want:%q", got, want)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		xmin, xmax, ymin, ymax float64
+		ok                     bool
+	}{
+		{xmin: 0, xmax: 1, ymin: 0, ymax: 1, ok: true},
+		{xmin: 1, xmax: 1, ymin: 0, ymax: 1, ok: true},
+		{xmin: 1, xmax: 0, ymin: 0, ymax: 1},
+		{xmin: 0, xmax: 1, ymin: math.NaN(), ymax: 1},
+		{xmin: math.Inf(1), xmax: 1, ymin: 0, ymax: 1},
+		{xmin: 0, xmax: 1, ymin: 0, ymax: math.Inf(-1)},
+		{xmin: math.Inf(1), xmax: math.Inf(-1), ymin: math.Inf(1), ymax: math.Inf(-1), ok: true},
+	}
+	for _, test := range tests {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("failed to create plot: %v", err)
+		}
+		p.X.Min, p.X.Max = test.xmin, test.xmax
+		p.Y.Min, p.Y.Max = test.ymin, test.ymax
+		err = p.Validate()
+		if (err == nil) != test.ok {
+			t.Errorf("unexpected error for X=[%g,%g] Y=[%g,%g]: %v", test.xmin, test.xmax, test.ymin, test.ymax, err)
+		}
+		if _, werr := p.WriterTo(1, 1, "svg"); (werr == nil) != test.ok {
+			t.Errorf("unexpected WriterTo error for X=[%g,%g] Y=[%g,%g]: %v", test.xmin, test.xmax, test.ymin, test.ymax, werr)
+		}
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	if err := p.Validate(); err != nil {
+		t.Errorf("unexpected error for plot without data: %v", err)
+	}
+}
+
 func formatActions(actions []recorder.Action) string {
 	var buf bytes.Buffer
 	for _, a := range actions {
@@ -211,3 +293,870 @@ func formatActions(actions []recorder.Action) string {
 	}
 	return buf.String()
 }
+
+// canvasPlotter records the draw.Canvas that it is plotted to.
+type canvasPlotter struct{ c draw.Canvas }
+
+func (cp *canvasPlotter) Plot(c draw.Canvas, _ *plot.Plot) { cp.c = c }
+
+func TestDataCanvas(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.Title.Text = "Title"
+	p.X.Label.Text = "X"
+	p.Y.Label.Text = "Y"
+	s, err := plotter.NewScatter(plotter.XYs{{0, 0}, {10, 100}})
+	if err != nil {
+		t.Fatalf("failed to create scatter: %v", err)
+	}
+	var cp canvasPlotter
+	p.Add(s, &cp)
+
+	c := draw.NewCanvas(recorder.New(100), 4*vg.Inch, 3*vg.Inch)
+	p.Draw(c)
+	if got, want := p.DataCanvas(c).Rectangle, cp.c.Rectangle; got != want {
+		t.Errorf("unexpected data canvas: got:%+v want:%+v", got, want)
+	}
+}
+
+func TestPlotFont(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.Title.Text = "title"
+	p.X.Label.Text = "x"
+	p.Y.Label.Text = "y"
+	p.X.Min, p.X.Max = 0, 1
+	p.Y.Min, p.Y.Max = 0, 1
+	p.X.Tick.Marker = plot.ConstantTicks([]plot.Tick{{Value: 0.5, Label: "tick"}})
+	p.Y.Tick.Marker = plot.ConstantTicks([]plot.Tick{})
+	p.Y.Label.Font, err = vg.MakeFont("Courier", 14)
+	if err != nil {
+		t.Fatalf("failed to create font: %v", err)
+	}
+	p.Font.Name = "Helvetica"
+	p.Font.Size = 24
+
+	r := recorder.New(100)
+	p.Draw(draw.NewCanvas(r, 4*vg.Inch, 3*vg.Inch))
+
+	type font struct {
+		name string
+		size vg.Length
+	}
+	got := make(map[string]font)
+	for _, a := range r.Actions {
+		if fs, ok := a.(*recorder.FillString); ok {
+			got[fs.String] = font{fs.Font, fs.Size}
+		}
+	}
+	want := map[string]font{
+		"title": {"Helvetica", 24},
+		"x":     {"Helvetica", 24},
+		"y":     {"Courier", 14},
+		"tick":  {"Helvetica", 20},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected fonts: got:%v want:%v", got, want)
+	}
+	if name := p.Title.Font.Name(); name != plot.DefaultFont {
+		t.Errorf("Draw changed plot title font: got:%s want:%s", name, plot.DefaultFont)
+	}
+}
+
+func TestDrawGrid(t *testing.T) {
+	for _, same := range []bool{true, false} {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("failed to create plot: %v", err)
+		}
+		p.X.Min, p.X.Max = 0, 1
+		p.Y.Min, p.Y.Max = 0, 1
+		ticks := plot.ConstantTicks([]plot.Tick{{Value: 0.25, Label: "a"}, {Value: 0.5, Label: ""}, {Value: 0.75, Label: "b"}})
+		p.X.Tick.Marker = ticks
+		p.Y.Tick.Marker = ticks
+		p.X.GridStyle = draw.LineStyle{Color: color.Gray{128}, Width: 1}
+		p.Y.GridStyle = p.X.GridStyle
+		want := []int{8}
+		if !same {
+			p.Y.GridStyle.Color = color.Black
+			want = []int{4, 4}
+		}
+
+		r := recorder.New(100)
+		p.DrawGrid(draw.NewCanvas(r, 100, 100))
+		var got []int
+		for _, a := range r.Actions {
+			if s, ok := a.(*recorder.Stroke); ok {
+				got = append(got, len(s.Path))
+			}
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("unexpected grid strokes with same=%t: got path lengths:%v want:%v", same, got, want)
+		}
+	}
+}
+
+func TestIncludeZero(t *testing.T) {
+	tests := []struct {
+		xys      plotter.XYs
+		min, max float64
+	}{
+		{xys: plotter.XYs{{0, 5}, {1, 10}}, min: 0, max: 10},
+		{xys: plotter.XYs{{0, -5}, {1, -10}}, min: -10, max: 0},
+		{xys: plotter.XYs{{0, -5}, {1, 10}}, min: -5, max: 10},
+	}
+	for _, test := range tests {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("failed to create plot: %v", err)
+		}
+		p.Y.IncludeZero = true
+		s, err := plotter.NewScatter(test.xys)
+		if err != nil {
+			t.Fatalf("failed to create scatter: %v", err)
+		}
+		p.Add(s)
+		p.Draw(draw.NewCanvas(recorder.New(100), 100, 100))
+		if p.Y.Min != test.min || p.Y.Max != test.max {
+			t.Errorf("unexpected Y range for %v: got:[%g,%g] want:[%g,%g]", test.xys, p.Y.Min, p.Y.Max, test.min, test.max)
+		}
+	}
+}
+
+func TestHideOriginLabel(t *testing.T) {
+	for _, test := range []struct {
+		hide     bool
+		min      float64
+		wantZero int
+	}{
+		{hide: false, min: 0, wantZero: 2},
+		{hide: true, min: 0, wantZero: 1},
+		{hide: true, min: -1, wantZero: 2},
+	} {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("failed to create plot: %v", err)
+		}
+		p.HideOriginLabel = test.hide
+		p.X.Min, p.X.Max = test.min, 1
+		p.Y.Min, p.Y.Max = 0, 1
+		ticks := plot.ConstantTicks([]plot.Tick{{Value: 0, Label: "0"}, {Value: 1, Label: "1"}})
+		p.X.Tick.Marker = ticks
+		p.Y.Tick.Marker = ticks
+
+		r := recorder.New(100)
+		p.Draw(draw.NewCanvas(r, 100, 100))
+		var zeros int
+		for _, a := range r.Actions {
+			if fs, ok := a.(*recorder.FillString); ok && fs.String == "0" {
+				zeros++
+			}
+		}
+		if zeros != test.wantZero {
+			t.Errorf("unexpected number of zero labels for hide=%t X.Min=%g: got:%d want:%d",
+				test.hide, test.min, zeros, test.wantZero)
+		}
+	}
+}
+
+func TestSuggestSize(t *testing.T) {
+	for _, test := range []struct {
+		xmax, ymax float64
+		dpi        int
+		aspect     float64
+	}{
+		{xmax: 10, ymax: 10, dpi: 96, aspect: 1},
+		{xmax: 15, ymax: 10, dpi: 96, aspect: 1.5},
+		{xmax: 1000, ymax: 1, dpi: 96, aspect: 2},
+		{xmax: 1, ymax: 1000, dpi: 96, aspect: 0.5},
+		{xmax: 10, ymax: 10, dpi: 20, aspect: 1},
+	} {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("failed to create plot: %v", err)
+		}
+		p.Title.Text = "Title"
+		p.X.Min, p.X.Max = 0, test.xmax
+		p.Y.Min, p.Y.Max = 0, test.ymax
+		w, h := p.SuggestSize(test.dpi)
+
+		c := p.DataCanvas(draw.NewCanvas(recorder.New(float64(test.dpi)), w, h))
+		sz := c.Size()
+		if got := float64(sz.X / sz.Y); math.Abs(got-test.aspect) > 0.05 {
+			t.Errorf("unexpected data aspect for X=[0,%g] Y=[0,%g]: got:%g want:%g", test.xmax, test.ymax, got, test.aspect)
+		}
+		if dots := math.Min(w.Points(), h.Points()) / vg.Inch.Points() * float64(test.dpi); dots < 300-1e-9 {
+			t.Errorf("image too small at %d dpi: got:%g dots", test.dpi, dots)
+		}
+	}
+}
+
+func TestNegativePadding(t *testing.T) {
+	size := func(pad vg.Length, w, h vg.Length) draw.Rectangle {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("failed to create plot: %v", err)
+		}
+		p.X.Min, p.X.Max = 0, 1
+		p.Y.Min, p.Y.Max = 0, 1
+		p.X.Padding = pad
+		p.Y.Padding = pad
+		return p.DataCanvas(draw.NewCanvas(recorder.New(72), w, h)).Rectangle
+	}
+
+	zero := size(0, 200, 200)
+	neg := size(-3, 200, 200)
+	if d := zero.Min.Y - neg.Min.Y; math.Abs(float64(d-3)) > 1e-9 {
+		t.Errorf("unexpected vertical overdraw: got:%v want:3", d)
+	}
+	if d := zero.Min.X - neg.Min.X; math.Abs(float64(d-3)) > 1e-9 {
+		t.Errorf("unexpected horizontal overdraw: got:%v want:3", d)
+	}
+
+	if r := size(-1000, 200, 200); r.Min.X < 0 || r.Min.Y < 0 {
+		t.Errorf("data area extends past the canvas: %+v", r)
+	}
+
+	if r := size(5, 10, 10); r.Min.X > r.Max.X || r.Min.Y > r.Max.Y {
+		t.Errorf("inverted data area on a small canvas: %+v", r)
+	}
+}
+
+func TestSaveGIF(t *testing.T) {
+	var frames []*plot.Plot
+	for i := 0; i < 3; i++ {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("failed to create plot: %v", err)
+		}
+		p.X.Min, p.X.Max = 0, 3
+		p.Y.Min, p.Y.Max = 0, 3
+		l, err := plotter.NewLine(plotter.XYs{{0, 0}, {float64(i), float64(i)}})
+		if err != nil {
+			t.Fatalf("failed to create line: %v", err)
+		}
+		p.Add(l)
+		frames = append(frames, p)
+	}
+
+	dir, err := ioutil.TempDir("", "plot")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "anim.gif")
+	if err := plot.SaveGIF(frames, 2*vg.Inch, vg.Inch, 144, 10, name); err != nil {
+		t.Fatalf("failed to save GIF: %v", err)
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatalf("failed to open GIF: %v", err)
+	}
+	defer f.Close()
+	anim, err := gif.DecodeAll(f)
+	if err != nil {
+		t.Fatalf("failed to decode GIF: %v", err)
+	}
+	if len(anim.Image) != len(frames) {
+		t.Fatalf("unexpected number of frames: got:%d want:%d", len(anim.Image), len(frames))
+	}
+	// The frames are 2×1 inches at 144 dots per inch.
+	want := image.Rect(0, 0, 288, 144)
+	for i, img := range anim.Image {
+		if img.Bounds() != want {
+			t.Errorf("unexpected bounds for frame %d: got:%v want:%v", i, img.Bounds(), want)
+		}
+		if anim.Delay[i] != 10 {
+			t.Errorf("unexpected delay for frame %d: got:%d want:10", i, anim.Delay[i])
+		}
+	}
+
+	if err := plot.SaveGIF(nil, vg.Inch, vg.Inch, 144, 10, name); err == nil {
+		t.Error("expected error for no frames")
+	}
+}
+
+func TestRenderToImage(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	l, err := plotter.NewLine(plotter.XYs{{0, 0}, {1, 1}})
+	if err != nil {
+		t.Fatalf("failed to create line: %v", err)
+	}
+	p.Add(l)
+	for _, dpi := range []int{72, 150} {
+		img, err := plot.RenderToImage(p, 2*vg.Inch, vg.Inch, dpi)
+		if err != nil {
+			t.Fatalf("failed to render at %d dpi: %v", dpi, err)
+		}
+		want := image.Rect(0, 0, 2*dpi, dpi)
+		if img.Bounds() != want {
+			t.Errorf("unexpected bounds at %d dpi: got:%v want:%v", dpi, img.Bounds(), want)
+		}
+		if got := vgimg.NewWithDPI(vg.Inch, vg.Inch, dpi).DPI(); got != float64(dpi) {
+			t.Errorf("unexpected canvas dpi: got:%g want:%d", got, dpi)
+		}
+	}
+	if _, err := plot.RenderToImage(p, vg.Inch, vg.Inch, 0); err == nil {
+		t.Error("expected error for zero dpi")
+	}
+}
+
+func TestDrawWithInfo(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 1
+	p.Y.Min, p.Y.Max = 0, 1
+	c := draw.NewCanvas(recorder.New(72), 200, 200)
+	info := p.DrawWithInfo(c)
+	if len(info.Warnings) != 0 {
+		t.Errorf("unexpected warnings: %q", info.Warnings)
+	}
+	if want := p.DataCanvas(c).Rectangle; info.DataArea != want {
+		t.Errorf("unexpected data area: got:%v want:%v", info.DataArea, want)
+	}
+
+	p.Y.Min, p.Y.Max = 5, 5
+	p.X.Tick.Marker = plot.ConstantTicks([]plot.Tick{{Value: 0, Label: "a long tick label"}, {Value: 0.25, Label: "another long label"}})
+	info = p.DrawWithInfo(c)
+	want := []string{
+		"Y axis range is empty: expanded about 5",
+		"X axis tick labels overlap",
+	}
+	if !reflect.DeepEqual(info.Warnings, want) {
+		t.Errorf("unexpected warnings: got:%q want:%q", info.Warnings, want)
+	}
+}
+
+func TestDrawEmpty(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	r := recorder.New(72)
+	info := p.DrawWithInfo(draw.NewCanvas(r, 200, 200))
+	want := []string{
+		"plot has no data",
+		"X axis range is not set: using 0 to 1",
+		"Y axis range is not set: using 0 to 1",
+	}
+	if !reflect.DeepEqual(info.Warnings, want) {
+		t.Errorf("unexpected warnings: got:%q want:%q", info.Warnings, want)
+	}
+	if p.X.Min != 0 || p.X.Max != 1 || p.Y.Min != 0 || p.Y.Max != 1 {
+		t.Errorf("unexpected ranges: X:%g,%g Y:%g,%g want 0,1", p.X.Min, p.X.Max, p.Y.Min, p.Y.Max)
+	}
+	var labels []string
+	for _, a := range r.Actions {
+		switch a := a.(type) {
+		case *recorder.FillString:
+			labels = append(labels, a.String)
+		case *recorder.Stroke:
+			for _, c := range a.Path {
+				if math.IsNaN(float64(c.X)) || math.IsNaN(float64(c.Y)) {
+					t.Fatalf("NaN in stroked path: %v", a.Path)
+				}
+			}
+		}
+	}
+	if len(labels) == 0 || labels[0] != "0" {
+		t.Errorf("axes not drawn over the unit range: labels %q", labels)
+	}
+}
+
+func TestSaveEmpty(t *testing.T) {
+	empty, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	gauge, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	g, err := plotter.NewGauge(30, 0, 100)
+	if err != nil {
+		t.Fatalf("failed to create gauge: %v", err)
+	}
+	gauge.Add(g)
+	gauge.HideAxes()
+
+	dir, err := ioutil.TempDir("", "plot")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	for _, test := range []struct {
+		name string
+		p    *plot.Plot
+	}{
+		{name: "empty", p: empty},
+		{name: "gauge", p: gauge},
+	} {
+		for _, ext := range []string{"svg", "png"} {
+			name := filepath.Join(dir, test.name+"."+ext)
+			if err := test.p.Save(2*vg.Inch, vg.Inch, name); err != nil {
+				t.Errorf("failed to save %s plot as %s: %v", test.name, ext, err)
+				continue
+			}
+			b, err := ioutil.ReadFile(name)
+			if err != nil {
+				t.Errorf("failed to read %s plot as %s: %v", test.name, ext, err)
+				continue
+			}
+			if len(b) == 0 {
+				t.Errorf("empty file for %s plot as %s", test.name, ext)
+			}
+			if ext != "png" {
+				continue
+			}
+			img, _, err := image.Decode(bytes.NewReader(b))
+			if err != nil {
+				t.Errorf("failed to decode %s plot: %v", test.name, err)
+				continue
+			}
+			if want := vgimg.New(2*vg.Inch, vg.Inch).Image().Bounds(); img.Bounds() != want {
+				t.Errorf("unexpected bounds for %s plot: got:%v want:%v", test.name, img.Bounds(), want)
+			}
+		}
+	}
+}
+
+func TestPlotFontScaleLengths(t *testing.T) {
+	for _, scale := range []bool{false, true} {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("failed to create plot: %v", err)
+		}
+		p.HideY()
+		p.X.Min, p.X.Max = 0, 1
+		p.Y.Min, p.Y.Max = 0, 1
+		p.X.Tick.Marker = plot.NewConstantTicks([]plot.Tick{{Value: 0.5, Label: "tick"}}, []float64{0.25})
+		p.Font.Size = 24
+		p.Font.ScaleLengths = scale
+
+		// Changing the defaults after the plot is made
+		// does not change which of its lengths are scaled.
+		def := plot.DefaultTickLength
+		plot.DefaultTickLength = 3
+		r := recorder.New(72)
+		p.Draw(draw.NewCanvas(r, 200, 200))
+		plot.DefaultTickLength = def
+		var got []vg.Length
+		for _, a := range r.Actions {
+			if s, ok := a.(*recorder.Stroke); ok && len(s.Path) == 4 {
+				for i := 0; i < len(s.Path); i += 2 {
+					got = append(got, s.Path[i+1].Y-s.Path[i].Y)
+				}
+			}
+		}
+		want := []vg.Length{8, 4}
+		if scale {
+			want = []vg.Length{16, 8}
+		}
+		if len(got) != len(want) {
+			t.Fatalf("unexpected number of ticks with ScaleLengths=%t: got:%d want:%d", scale, len(got), len(want))
+		}
+		for i := range got {
+			if math.Abs(float64(got[i]-want[i])) > 1e-9 {
+				t.Errorf("unexpected tick lengths with ScaleLengths=%t: got:%v want:%v", scale, got, want)
+				break
+			}
+		}
+	}
+}
+
+func TestMinSize(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.Title.Text = "title"
+	p.X.Label.Text = "x"
+	p.Y.Label.Text = "y"
+	p.X.Min, p.X.Max = 0, 1
+	p.Y.Min, p.Y.Max = 0, 1
+	p.X.Tick.Marker = plot.ConstantTicks([]plot.Tick{{Value: 0, Label: "first label"}, {Value: 0.5, Label: "second label"}, {Value: 1, Label: "third label"}})
+	p.Y.Tick.Marker = plot.ConstantTicks([]plot.Tick{{Value: 0, Label: "0"}, {Value: 0.1, Label: "0.1"}, {Value: 0.2, Label: "0.2"}, {Value: 1, Label: "1"}})
+
+	w, h := p.MinSize()
+	info := p.DrawWithInfo(draw.NewCanvas(recorder.New(72), w, h))
+	if len(info.Warnings) != 0 {
+		t.Errorf("unexpected warnings at minimum size %vx%v: %q", w, h, info.Warnings)
+	}
+	for _, test := range []struct {
+		w, h vg.Length
+		want string
+	}{
+		{w: w * 0.9, h: h, want: "X axis tick labels overlap"},
+		{w: w, h: h * 0.9, want: "Y axis tick labels overlap"},
+	} {
+		info := p.DrawWithInfo(draw.NewCanvas(recorder.New(72), test.w, test.h))
+		if !reflect.DeepEqual(info.Warnings, []string{test.want}) {
+			t.Errorf("unexpected warnings at %vx%v: got:%q want:%q", test.w, test.h, info.Warnings, test.want)
+		}
+	}
+}
+
+func TestGlyphAtAxisMax(t *testing.T) {
+	for _, noPad := range []bool{false, true} {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("failed to create plot: %v", err)
+		}
+		p.HideAxes()
+		s, err := plotter.NewScatter(plotter.XYs{{0, 0}, {1, 1}})
+		if err != nil {
+			t.Fatalf("failed to create scatter: %v", err)
+		}
+		s.Shape = draw.CircleGlyph{}
+		s.Radius = 5
+		p.Add(s)
+		p.NoGlyphPadding = noPad
+
+		const size = 100
+		r := recorder.New(72)
+		p.Draw(draw.NewCanvas(r, size, size))
+		var found, inside bool
+		for _, a := range r.Actions {
+			f, ok := a.(*recorder.Fill)
+			if !ok || len(f.Path) != 3 || f.Path[1].Type != vg.ArcComp {
+				continue
+			}
+			x, y := f.Path[1].X, f.Path[1].Y
+			if x < size/2 {
+				continue
+			}
+			found = true
+			inside = x+s.Radius <= size && y+s.Radius <= size
+		}
+		if !found {
+			t.Fatalf("glyph at the maximum not drawn with NoGlyphPadding=%t", noPad)
+		}
+		if inside == noPad {
+			t.Errorf("unexpected containment of glyph at the maximum with NoGlyphPadding=%t: got:%t", noPad, inside)
+		}
+	}
+}
+
+func TestTransformXs(t *testing.T) {
+	xs := []float64{1, 2, 5, 10, 50, 100}
+	for _, scale := range []plot.Normalizer{plot.LinearScale{}, plot.LogScale{}} {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("failed to create plot: %v", err)
+		}
+		p.X.Min, p.X.Max = 1, 100
+		p.Y.Min, p.Y.Max = 1, 100
+		p.X.Scale, p.Y.Scale = scale, scale
+		c := draw.NewCanvas(recorder.New(72), 300, 200)
+		trX, trY := p.Transforms(&c)
+		gotX, gotY := p.TransformXs(&c, xs), p.TransformYs(&c, xs)
+		for i, x := range xs {
+			if math.Abs(float64(gotX[i]-trX(x))) > 1e-9 || math.Abs(float64(gotY[i]-trY(x))) > 1e-9 {
+				t.Errorf("unexpected transform of %g for %T: got:%v,%v want:%v,%v",
+					x, scale, gotX[i], gotY[i], trX(x), trY(x))
+			}
+		}
+	}
+}
+
+func benchmarkTransformXs(b *testing.B, bulk bool) {
+	xs := make([]float64, 1e6)
+	for i := range xs {
+		xs[i] = float64(i)
+	}
+	p, err := plot.New()
+	if err != nil {
+		b.Fatalf("failed to create plot: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, float64(len(xs)-1)
+	c := draw.NewCanvas(recorder.New(96), 600, 400)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if bulk {
+			p.TransformXs(&c, xs)
+			continue
+		}
+		trX, _ := p.Transforms(&c)
+		ls := make([]vg.Length, len(xs))
+		for j, x := range xs {
+			ls[j] = trX(x)
+		}
+	}
+}
+
+func BenchmarkTransformXs1e6(b *testing.B)     { benchmarkTransformXs(b, true) }
+func BenchmarkTransformScalar1e6(b *testing.B) { benchmarkTransformXs(b, false) }
+
+func TestFooter(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 1
+	p.Y.Min, p.Y.Max = 0, 1
+	const text = "source: example.com"
+	footer := func() *recorder.FillString {
+		r := recorder.New(72)
+		p.Draw(draw.NewCanvas(r, 200, 100))
+		var found *recorder.FillString
+		for _, a := range r.Actions {
+			if s, ok := a.(*recorder.FillString); ok && s.String == text {
+				found = s
+			}
+		}
+		return found
+	}
+
+	if footer() != nil {
+		t.Error("unexpected footer drawn with no text")
+	}
+	p.Footer.Text = text
+	w := p.Footer.Width(text)
+	for _, left := range []bool{false, true} {
+		p.Footer.Left = left
+		s := footer()
+		if s == nil {
+			t.Fatalf("no footer drawn for left=%t", left)
+		}
+		want := 200 - p.Footer.Padding - w
+		if left {
+			want = p.Footer.Padding
+		}
+		if math.Abs(float64(s.X-want)) > 1e-9 {
+			t.Errorf("unexpected footer position for left=%t: got:%v want:%v", left, s.X, want)
+		}
+		if bottom := s.Y + p.Footer.Font.Extents().Descent; bottom < p.Footer.Padding || bottom > 2*p.Footer.Padding {
+			t.Errorf("unexpected footer bottom for left=%t: got:%v want:%v-%v",
+				left, bottom, p.Footer.Padding, 2*p.Footer.Padding)
+		}
+	}
+}
+
+func TestTickLabelsInsideCanvas(t *testing.T) {
+	const w, h = 200, 150
+	for _, scale := range []float64{1e-9, 1, 1e12} {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("failed to create plot: %v", err)
+		}
+		p.X.Label.Text = "X"
+		p.Y.Label.Text = "Y"
+		p.X.Tick.Label.Font.Size = 16
+		p.Y.Tick.Label.Font.Size = 16
+		l, err := plotter.NewLine(plotter.XYs{{1.2345 * scale, 1.2345 * scale}, {9.87 * scale, 98.7 * scale}})
+		if err != nil {
+			t.Fatalf("failed to create line: %v", err)
+		}
+		p.Add(l)
+
+		r := recorder.New(72)
+		p.Draw(draw.NewCanvas(r, w, h))
+		for _, a := range r.Actions {
+			s, ok := a.(*recorder.FillString)
+			if !ok || s.String == "Y" {
+				// The Y axis label is drawn rotated.
+				continue
+			}
+			fnt, err := vg.MakeFont(s.Font, s.Size)
+			if err != nil {
+				t.Fatalf("failed to make font: %v", err)
+			}
+			const tol = 1e-9
+			if s.X < -tol || s.X+fnt.Width(s.String) > w+tol || s.Y+fnt.Extents().Descent < -tol || s.Y+fnt.Extents().Ascent > h+tol {
+				t.Errorf("label %q outside of the canvas for scale %g: x=%v y=%v", s.String, scale, s.X, s.Y)
+			}
+		}
+	}
+}
+
+func TestTopX(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 1
+	p.Y.Min, p.Y.Max = 0, 1
+	top, err := p.AddTopX(100, 200)
+	if err != nil {
+		t.Fatalf("failed to add top axis: %v", err)
+	}
+	top.Label.Text = "top"
+	top.Tick.Marker = plot.ConstantTicks([]plot.Tick{{Value: 100, Label: "100"}, {Value: 150, Label: "150"}, {Value: 200, Label: "200"}})
+
+	r := recorder.New(72)
+	c := draw.NewCanvas(r, 200, 200)
+	info := p.DrawWithInfo(c)
+	if got := p.DataCanvas(c).Rectangle; got != info.DataArea {
+		t.Errorf("DataCanvas does not match the drawn data area: got:%v want:%v", got, info.DataArea)
+	}
+	if info.DataArea.Max.Y >= 200-top.Padding {
+		t.Errorf("no space reserved for the top axis: data area top=%v", info.DataArea.Max.Y)
+	}
+
+	labels := make(map[string]*recorder.FillString)
+	for _, a := range r.Actions {
+		if s, ok := a.(*recorder.FillString); ok {
+			labels[s.String] = s
+		}
+	}
+	for _, l := range []string{"top", "100", "150", "200"} {
+		s, ok := labels[l]
+		if !ok {
+			t.Errorf("top axis label %q not drawn", l)
+			continue
+		}
+		if s.Y <= info.DataArea.Max.Y {
+			t.Errorf("top axis label %q drawn below the top of the data area: y=%v top=%v", l, s.Y, info.DataArea.Max.Y)
+		}
+	}
+	if s, ok := labels["150"]; ok {
+		mid := (info.DataArea.Min.X + info.DataArea.Max.X) / 2
+		w := p.X.Tick.Label.Width("150")
+		if math.Abs(float64(s.X+w/2-mid)) > 1e-9 {
+			t.Errorf("top axis label not centered over the data area: got:%v want:%v", s.X+w/2, mid)
+		}
+	}
+	if labels["top"].Y <= labels["150"].Y {
+		t.Error("top axis label is not above its tick labels")
+	}
+}
+
+func TestPercentileRange(t *testing.T) {
+	xys := make(plotter.XYs, 101)
+	for i := range xys {
+		xys[i].X = float64(i)
+		xys[i].Y = float64(i)
+	}
+	xys[100].Y = 1e6
+	s, err := plotter.NewScatter(xys)
+	if err != nil {
+		t.Fatalf("failed to create scatter: %v", err)
+	}
+	b, err := plotter.NewBarChart(plotter.Values{-10, 5}, 10)
+	if err != nil {
+		t.Fatalf("failed to create bar chart: %v", err)
+	}
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.Add(s)
+	p.PercentileRange(5, 95)
+	if p.X.Min != 5 || p.X.Max != 95 || p.Y.Min != 5 || p.Y.Max != 95 {
+		t.Errorf("unexpected range: got:X=[%g,%g] Y=[%g,%g] want:X=[5,95] Y=[5,95]",
+			p.X.Min, p.X.Max, p.Y.Min, p.Y.Max)
+	}
+
+	// Plotters without values contribute their full range.
+	p.Add(b)
+	p.PercentileRange(5, 95)
+	if p.Y.Min != -10 || p.Y.Max != 95 {
+		t.Errorf("unexpected Y range with a bar chart: got:[%g,%g] want:[-10,95]", p.Y.Min, p.Y.Max)
+	}
+}
+
+func TestLegendAddPlots(t *testing.T) {
+	var plots []*plot.Plot
+	for i := 0; i < 2; i++ {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("failed to create plot: %v", err)
+		}
+		for _, name := range []string{"a", "b", ""} {
+			l, err := plotter.NewLine(plotter.XYs{{0, 0}, {1, float64(i)}})
+			if err != nil {
+				t.Fatalf("failed to create line: %v", err)
+			}
+			l.Name = name
+			p.Add(l)
+		}
+		plots = append(plots, p)
+	}
+
+	l, err := plot.NewLegend()
+	if err != nil {
+		t.Fatalf("failed to create legend: %v", err)
+	}
+	l.AddPlots(plots...)
+	w, h := l.Size()
+	if w <= l.ThumbnailWidth || h <= 0 {
+		t.Errorf("unexpected legend size: got:%vx%v", w, h)
+	}
+
+	r := recorder.New(72)
+	l.Draw(draw.NewCanvas(r, w, h))
+	var names []string
+	for _, a := range r.Actions {
+		if s, ok := a.(*recorder.FillString); ok {
+			names = append(names, s.String)
+		}
+	}
+	if want := []string{"a", "b"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("unexpected legend entries: got:%q want:%q", names, want)
+	}
+}
+
+func TestLayoutDefaults(t *testing.T) {
+	defer func(pad, tick, minor, width vg.Length) {
+		plot.DefaultPadding = pad
+		plot.DefaultTickLength = tick
+		plot.DefaultMinorTickLength = minor
+		plot.DefaultLineWidth = width
+	}(plot.DefaultPadding, plot.DefaultTickLength, plot.DefaultMinorTickLength, plot.DefaultLineWidth)
+
+	before, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	plot.DefaultPadding = 1
+	plot.DefaultTickLength = 6
+	plot.DefaultMinorTickLength = 3
+	plot.DefaultLineWidth = 2
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	for _, a := range []*plot.Axis{&p.X, &p.Y} {
+		if a.Padding != 1 || a.Tick.Length != 6 || a.Tick.MinorLength != 3 || a.Width != 2 || a.Tick.Width != 2 {
+			t.Errorf("axis does not use the layout defaults: padding:%v length:%v minor:%v width:%v,%v",
+				a.Padding, a.Tick.Length, a.Tick.MinorLength, a.Width, a.Tick.Width)
+		}
+	}
+	if before.X.Padding != 5 || before.X.Tick.Length != 8 {
+		t.Errorf("earlier plot changed: padding:%v length:%v", before.X.Padding, before.X.Tick.Length)
+	}
+}
+
+func TestCategoryX(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	cats := p.CategoryX("apples", "pears", "plums")
+	if p.X.Min != -0.5 || p.X.Max != 2.5 {
+		t.Errorf("unexpected X range: got:[%g,%g] want:[-0.5,2.5]", p.X.Min, p.X.Max)
+	}
+	if got := cats.Index("pears"); got != 1 {
+		t.Errorf("unexpected index of pears: got:%g want:1", got)
+	}
+	if got := cats.Index("figs"); !math.IsNaN(got) {
+		t.Errorf("unexpected index of figs: got:%g want:NaN", got)
+	}
+	want := []plot.Tick{{Value: 0, Label: "apples"}, {Value: 1, Label: "pears"}, {Value: 2, Label: "plums"}}
+	if got := p.X.Tick.Marker.Ticks(p.X.Min, p.X.Max); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected ticks: got:%v want:%v", got, want)
+	}
+	if got := cats.Ticks(1.5, 2.5); len(got) != 1 || got[0].Label != "plums" {
+		t.Errorf("unexpected ticks in [1.5,2.5]: got:%v want only plums", got)
+	}
+}
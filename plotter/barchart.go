@@ -37,6 +37,35 @@ type BarChart struct {
 	// bar charts.
 	XMin float64
 
+	// ValueLabel specifies text giving the value
+	// of each bar, centered on the bar beyond its
+	// end: above the top of bars with non-negative
+	// values and below the bottom of bars with
+	// negative values.
+	ValueLabel struct {
+		// Format returns the label of a bar with
+		// the given value.  If Format is nil then
+		// no value labels are drawn.
+		Format func(float64) string
+
+		// Inside specifies that the labels are
+		// drawn just inside the end of the bar
+		// rather than beyond it.
+		Inside bool
+
+		// Padding is the distance between
+		// the end of the bar and its label.
+		Padding vg.Length
+
+		draw.TextStyle
+	}
+
+	// Name is the name of the bar chart in the
+	// plot's legend.  If Name is the empty string
+	// then the bar chart is not added to the
+	// legend by plot.AutoLegend.
+	Name string
+
 	// stackedOn is the bar chart upon which
 	// this bar chart is stacked.
 	stackedOn *BarChart
@@ -53,12 +82,22 @@ func NewBarChart(vs Valuer, width vg.Length) (*BarChart, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &BarChart{
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	b := &BarChart{
 		Values:    values,
 		Width:     width,
 		Color:     color.Black,
 		LineStyle: DefaultLineStyle,
-	}, nil
+	}
+	b.ValueLabel.Padding = vg.Points(2)
+	b.ValueLabel.TextStyle = draw.TextStyle{
+		Color: color.Black,
+		Font:  fnt,
+	}
+	return b, nil
 }
 
 // BarHeight returns the maximum y value of the
@@ -115,9 +154,24 @@ func (b *BarChart) Plot(c draw.Canvas, plt *plot.Plot) {
 		pts = append(pts, draw.Point{xmin, ymin})
 		outline := c.ClipLinesY(pts)
 		c.StrokeLines(b.LineStyle, outline...)
+
+		if b.ValueLabel.Format != nil {
+			y, yalign := b.valueLabelY(ht, ymax)
+			c.FillText(b.ValueLabel.TextStyle, xmin+b.Width/2, y, -0.5, yalign, b.ValueLabel.Format(ht))
+		}
 	}
 }
 
+// valueLabelY returns the vertical position and alignment
+// of the value label of a bar with the given value and end.
+func (b *BarChart) valueLabelY(v float64, end vg.Length) (y vg.Length, yalign float64) {
+	l := &b.ValueLabel
+	if above := (v >= 0) != l.Inside; above {
+		return end + l.Padding - l.Font.Extents().Descent, 0
+	}
+	return end - l.Padding, -1
+}
+
 // DataRange implements the plot.DataRanger interface.
 func (b *BarChart) DataRange() (xmin, xmax, ymin, ymax float64) {
 	xmin = b.XMin
@@ -145,6 +199,28 @@ func (b *BarChart) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 			Max: draw.Point{X: b.Offset + b.Width/2},
 		}
 	}
+	if b.ValueLabel.Format == nil || b.ValueLabel.Inside {
+		return boxes
+	}
+	// The value labels beyond the ends
+	// of the bars must not be clipped.
+	for i, v := range b.Values {
+		txt := b.ValueLabel.Format(v)
+		w := b.ValueLabel.Width(txt)
+		h := b.ValueLabel.Height(txt) - b.ValueLabel.Font.Extents().Descent + b.ValueLabel.Padding
+		box := plot.GlyphBox{
+			X: plt.X.Norm(b.XMin + float64(i)),
+			Y: plt.Y.Norm(b.BarHeight(i)),
+			Rectangle: draw.Rectangle{
+				Min: draw.Point{X: b.Offset - w/2},
+				Max: draw.Point{X: b.Offset + w/2, Y: h},
+			},
+		}
+		if v < 0 {
+			box.Rectangle.Min.Y, box.Rectangle.Max.Y = -h, 0
+		}
+		boxes = append(boxes, box)
+	}
 	return boxes
 }
 
@@ -162,3 +238,9 @@ func (b *BarChart) Thumbnail(c *draw.Canvas) {
 	outline := c.ClipLinesY(pts)
 	c.StrokeLines(b.LineStyle, outline...)
 }
+
+// LegendName returns the Name of the BarChart,
+// implementing the plot.LegendNamer interface.
+func (b *BarChart) LegendName() string {
+	return b.Name
+}
@@ -0,0 +1,68 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+func TestBarChartValueLabels(t *testing.T) {
+	b, err := NewBarChart(Values{2, -1}, 20)
+	if err != nil {
+		t.Fatalf("failed to create bar chart: %v", err)
+	}
+	b.ValueLabel.Format = func(v float64) string { return fmt.Sprintf("%.1f", v) }
+
+	for _, inside := range []bool{false, true} {
+		b.ValueLabel.Inside = inside
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("failed to create plot: %v", err)
+		}
+		p.HideAxes()
+		p.Add(b)
+		r := recorder.New(72)
+		c := draw.NewCanvas(r, 200, 200)
+		p.Draw(c)
+		da := p.DataCanvas(c)
+		trX, trY := p.Transforms(&da)
+
+		labels := make(map[string]*recorder.FillString)
+		for _, a := range r.Actions {
+			if s, ok := a.(*recorder.FillString); ok {
+				labels[s.String] = s
+			}
+		}
+		e := b.ValueLabel.Font.Extents()
+		for i, test := range []struct {
+			label string
+			v     float64
+		}{
+			{label: "2.0", v: 2},
+			{label: "-1.0", v: -1},
+		} {
+			s, ok := labels[test.label]
+			if !ok {
+				t.Fatalf("no label %q drawn for inside=%t", test.label, inside)
+			}
+			center := s.X + b.ValueLabel.Width(test.label)/2
+			if x := trX(float64(i)); math.Abs(float64(center-x)) > 1e-9 {
+				t.Errorf("label %q not centered for inside=%t: got:%v want:%v", test.label, inside, center, x)
+			}
+			end := trY(test.v)
+			above := s.Y+e.Descent >= end
+			if want := (test.v >= 0) != inside; above != want {
+				t.Errorf("unexpected label %q placement for inside=%t: baseline %v, bar end %v",
+					test.label, inside, s.Y, end)
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// BarGroup implements the Plotter interface, drawing
+// a set of bar charts as grouped bars.  The bars of the
+// charts at each X location form a group, and the width
+// of the bars is computed from the distance between
+// adjacent X locations, called the slot, and the gaps.
+//
+// The Width and Offset of the bar charts are ignored.
+type BarGroup struct {
+	// Bars are the bar charts that are grouped, in
+	// order from left to right within each group.
+	Bars []*BarChart
+
+	// GroupGap is the gap between adjacent groups
+	// as a fraction of the slot.
+	GroupGap float64
+
+	// BarGap is the gap between adjacent bars of
+	// a group as a fraction of the space allotted
+	// to each bar, which is the width of the group
+	// divided by the number of bar charts.
+	BarGap float64
+}
+
+// NewBarGroup returns a new BarGroup of the given bar
+// charts.  The gap between groups is 0.2 of the slot,
+// giving groups 0.8 of the slot wide, and the gap between
+// the bars of a group is 0.1 of the space for each bar.
+func NewBarGroup(bars ...*BarChart) (*BarGroup, error) {
+	if len(bars) == 0 {
+		return nil, errors.New("No bar charts to group")
+	}
+	return &BarGroup{
+		Bars:     bars,
+		GroupGap: 0.2,
+		BarGap:   0.1,
+	}, nil
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (g *BarGroup) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, _ := plt.Transforms(&c)
+	slot := trX(1) - trX(0)
+	if slot < 0 {
+		slot = -slot
+	}
+	// space is the space allotted to each bar.
+	space := slot * vg.Length(1-g.GroupGap) / vg.Length(len(g.Bars))
+	for i, b := range g.Bars {
+		bar := *b
+		bar.Width = space * vg.Length(1-g.BarGap)
+		bar.Offset = space * vg.Length(float64(i)-float64(len(g.Bars)-1)/2)
+		bar.Plot(c, plt)
+	}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.  The X range
+// is extended by half a slot on each side so that
+// the outer groups are drawn in full.
+func (g *BarGroup) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax = math.Inf(1), math.Inf(-1)
+	ymin, ymax = math.Inf(1), math.Inf(-1)
+	for _, b := range g.Bars {
+		bxmin, bxmax, bymin, bymax := b.DataRange()
+		xmin = math.Min(xmin, bxmin-0.5)
+		xmax = math.Max(xmax, bxmax+0.5)
+		ymin = math.Min(ymin, bymin)
+		ymax = math.Max(ymax, bymax)
+	}
+	return xmin, xmax, ymin, ymax
+}
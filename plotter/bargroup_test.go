@@ -0,0 +1,76 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+func TestBarGroup(t *testing.T) {
+	var bars []*BarChart
+	for _, vs := range []Values{{1, 2, 3}, {3, 2, 1}} {
+		b, err := NewBarChart(vs, 1)
+		if err != nil {
+			t.Fatalf("failed to create bar chart: %v", err)
+		}
+		bars = append(bars, b)
+	}
+	g, err := NewBarGroup(bars...)
+	if err != nil {
+		t.Fatalf("failed to create bar group: %v", err)
+	}
+	if xmin, xmax, _, _ := g.DataRange(); xmin != -0.5 || xmax != 2.5 {
+		t.Errorf("unexpected X range: got:[%g,%g] want:[-0.5,2.5]", xmin, xmax)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.Add(g)
+	r := recorder.New(72)
+	g.Plot(draw.NewCanvas(r, 300, 100), p)
+
+	// Each slot is 100 wide, giving groups 80 wide,
+	// 40 for each bar and bars 36 wide.
+	type span struct{ min, max vg.Length }
+	var got []span
+	for _, a := range r.Actions {
+		f, ok := a.(*recorder.Fill)
+		if !ok {
+			continue
+		}
+		s := span{min: vg.Length(math.Inf(1)), max: vg.Length(math.Inf(-1))}
+		for _, pc := range f.Path {
+			if pc.Type == vg.CloseComp {
+				continue
+			}
+			s.min = vg.Length(math.Min(float64(s.min), float64(pc.X)))
+			s.max = vg.Length(math.Max(float64(s.max), float64(pc.X)))
+		}
+		got = append(got, s)
+	}
+	want := []span{
+		{12, 48}, {112, 148}, {212, 248},
+		{52, 88}, {152, 188}, {252, 288},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected number of bars: got:%d want:%d", len(got), len(want))
+	}
+	for i := range got {
+		if math.Abs(float64(got[i].min-want[i].min)) > 1e-9 || math.Abs(float64(got[i].max-want[i].max)) > 1e-9 {
+			t.Errorf("unexpected extent of bar %d: got:%v want:%v", i, got[i], want[i])
+		}
+	}
+	if bars[0].Width != 1 || bars[0].Offset != 0 {
+		t.Errorf("bar chart modified: got width:%v offset:%v want width:1 offset:0", bars[0].Width, bars[0].Offset)
+	}
+}
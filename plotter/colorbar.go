@@ -0,0 +1,113 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// ColorBar implements the Plotter interface, drawing
+// the colors of a palette as a bar spanning the range
+// of values that they represent.  A ColorBar uses the
+// same mapping from values to colors as a HeatMap
+// with the same Palette, Min and Max.
+type ColorBar struct {
+	// Palette is the color palette drawn by the
+	// color bar.  Palette must not be nil or return
+	// a zero length []color.Color.
+	Palette palette.Palette
+
+	// Min and Max are the range of values
+	// represented by the color bar.
+	Min, Max float64
+
+	// Vertical specifies whether the values of the
+	// color bar are drawn along the Y axis.  If Vertical
+	// is false then they are drawn along the X axis.
+	Vertical bool
+
+	// Log specifies whether the colors are spaced
+	// on a logarithmic scale, matching a HeatMap
+	// or HexBin with Log set.  Min and Max must be
+	// positive if Log is true.  The axis along which
+	// the values are drawn should then have its Scale
+	// set to plot.LogScale and its Tick.Marker set to
+	// plot.LogTicks so that its ticks are log spaced.
+	Log bool
+}
+
+// NewColorBar returns a new vertical color bar of
+// the given palette representing the values from
+// min to max.
+func NewColorBar(p palette.Palette, min, max float64) *ColorBar {
+	return &ColorBar{
+		Palette:  p,
+		Min:      min,
+		Max:      max,
+		Vertical: true,
+	}
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (cb *ColorBar) Plot(c draw.Canvas, plt *plot.Plot) {
+	pal := cb.Palette.Colors()
+	if len(pal) == 0 {
+		panic("colorbar: empty palette")
+	}
+	min, max := cb.Min, cb.Max
+	if cb.Log {
+		min, max = math.Log(min), math.Log(max)
+	}
+	// d is the range of values represented
+	// by each color of the palette.
+	d := max - min
+	if len(pal) > 1 {
+		d /= float64(len(pal) - 1)
+	}
+
+	trX, trY := plt.Transforms(&c)
+	for i, col := range pal {
+		lo := math.Max(min, min+(float64(i)-0.5)*d)
+		hi := math.Min(max, min+(float64(i)+0.5)*d)
+		if len(pal) == 1 {
+			lo, hi = min, max
+		}
+		if cb.Log {
+			lo, hi = math.Exp(lo), math.Exp(hi)
+		}
+		var r draw.Rectangle
+		if cb.Vertical {
+			r = draw.Rectangle{
+				Min: draw.Point{c.Min.X, trY(lo)},
+				Max: draw.Point{c.Max.X, trY(hi)},
+			}
+		} else {
+			r = draw.Rectangle{
+				Min: draw.Point{trX(lo), c.Min.Y},
+				Max: draw.Point{trX(hi), c.Max.Y},
+			}
+		}
+		pts := []draw.Point{
+			r.Min,
+			{r.Max.X, r.Min.Y},
+			r.Max,
+			{r.Min.X, r.Max.Y},
+		}
+		c.FillPolygon(col, c.ClipPolygonXY(pts))
+	}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (cb *ColorBar) DataRange() (xmin, xmax, ymin, ymax float64) {
+	if cb.Vertical {
+		return 0, 1, cb.Min, cb.Max
+	}
+	return cb.Min, cb.Max, 0, 1
+}
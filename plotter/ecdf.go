@@ -0,0 +1,90 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// ECDF implements the Plotter interface, drawing the
+// empirical cumulative distribution function of a set of
+// values.  The function is drawn as a step line that rises
+// from zero to one, stepping up at each of the values.
+type ECDF struct {
+	// Values are the values of the distribution,
+	// in increasing order.
+	Values
+
+	// Counts specifies that the line gives the number
+	// of values at or below each point, rising to the
+	// number of values, instead of their fraction.
+	Counts bool
+
+	// LineStyle is the style of the step line.
+	draw.LineStyle
+}
+
+// NewECDF returns an ECDF of a sorted copy of the
+// given values.  An error is returned if there are
+// no values.
+func NewECDF(vs Valuer) (*ECDF, error) {
+	if vs.Len() == 0 {
+		return nil, ErrNoData
+	}
+	values, err := CopyValues(vs)
+	if err != nil {
+		return nil, err
+	}
+	sort.Float64s(values)
+	return &ECDF{
+		Values:    values,
+		LineStyle: DefaultLineStyle,
+	}, nil
+}
+
+// top returns the height of the ECDF
+// above the greatest of its values.
+func (e *ECDF) top() float64 {
+	if e.Counts {
+		return float64(len(e.Values))
+	}
+	return 1
+}
+
+// Steps returns the corners of the step line of the
+// ECDF, which rises vertically at each of the values
+// and is horizontal between them.
+func (e *ECDF) Steps() XYs {
+	step := e.top() / float64(len(e.Values))
+	pts := make(XYs, 0, 2*len(e.Values))
+	var y float64
+	for _, v := range e.Values {
+		pts = append(pts, struct{ X, Y float64 }{v, y})
+		y += step
+		pts = append(pts, struct{ X, Y float64 }{v, y})
+	}
+	return pts
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (e *ECDF) Plot(c draw.Canvas, plt *plot.Plot) {
+	l := Line{XYs: e.Steps(), LineStyle: e.LineStyle}
+	l.Plot(c, plt)
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (e *ECDF) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return e.Values[0], e.Values[len(e.Values)-1], 0, e.top()
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (e *ECDF) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	c.StrokeLine2(e.LineStyle, c.Min.X, y, c.Max.X, y)
+}
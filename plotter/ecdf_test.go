@@ -0,0 +1,38 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestECDF(t *testing.T) {
+	e, err := NewECDF(Values{3, 1, 2, 2})
+	if err != nil {
+		t.Fatalf("failed to create ECDF: %v", err)
+	}
+	want := XYs{
+		{1, 0}, {1, 0.25},
+		{2, 0.25}, {2, 0.5},
+		{2, 0.5}, {2, 0.75},
+		{3, 0.75}, {3, 1},
+	}
+	if got := e.Steps(); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected steps: got:%v want:%v", got, want)
+	}
+
+	e.Counts = true
+	if _, xmax, _, ymax := e.DataRange(); xmax != 3 || ymax != 4 {
+		t.Errorf("unexpected range maximum for counts: got:%g,%g want:3,4", xmax, ymax)
+	}
+	if steps := e.Steps(); steps[len(steps)-1].Y != 4 {
+		t.Errorf("unexpected final count: got:%g want:4", steps[len(steps)-1].Y)
+	}
+
+	if _, err := NewECDF(Values{}); err != ErrNoData {
+		t.Errorf("unexpected error for no values: %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// ConfidenceEllipse implements the Plotter interface,
+// drawing the ellipse that contains the given fraction of
+// a bivariate normal distribution with the mean and the
+// covariance of a set of points.  It is typically drawn
+// over a Scatter of the points.
+type ConfidenceEllipse struct {
+	// X and Y are the mean of the points,
+	// which is the center of the ellipse.
+	X, Y float64
+
+	// VarX and VarY are the sample variances
+	// of the X and Y values of the points, and
+	// CovXY is their sample covariance.
+	VarX, VarY, CovXY float64
+
+	// Confidence is the fraction of the distribution
+	// contained by the ellipse, for example 0.95.
+	Confidence float64
+
+	// LineStyle is the style of the outline.
+	draw.LineStyle
+
+	// Color is the fill color of the ellipse.  If
+	// Color is nil then the ellipse is not filled.
+	Color color.Color
+}
+
+// NewConfidenceEllipse returns a ConfidenceEllipse for the given
+// points at the given confidence level, which must be between
+// zero and one.
+//
+// If the points are collinear then the ellipse is drawn as a
+// line segment, and if they are all equal nothing is drawn.
+func NewConfidenceEllipse(xys XYer, confidence float64) (*ConfidenceEllipse, error) {
+	if !(confidence > 0 && confidence < 1) {
+		return nil, errors.New("Confidence level not between 0 and 1")
+	}
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 2 {
+		return nil, errors.New("Too few points for a confidence ellipse")
+	}
+
+	e := &ConfidenceEllipse{
+		Confidence: confidence,
+		LineStyle:  DefaultLineStyle,
+	}
+	for _, d := range data {
+		e.X += d.X
+		e.Y += d.Y
+	}
+	n := float64(len(data))
+	e.X /= n
+	e.Y /= n
+	for _, d := range data {
+		dx, dy := d.X-e.X, d.Y-e.Y
+		e.VarX += dx * dx
+		e.VarY += dy * dy
+		e.CovXY += dx * dy
+	}
+	e.VarX /= n - 1
+	e.VarY /= n - 1
+	e.CovXY /= n - 1
+	return e, nil
+}
+
+// scale returns the number of standard deviations along
+// each principal axis to the edge of the ellipse.  For two
+// degrees of freedom the chi-squared quantile of p is
+// -2 ln(1-p).
+func (e *ConfidenceEllipse) scale() float64 {
+	return math.Sqrt(-2 * math.Log(1-e.Confidence))
+}
+
+// Outline returns n points evenly spaced in angle around
+// the outline of the ellipse in data coordinates.
+func (e *ConfidenceEllipse) Outline(n int) XYs {
+	// The principal axes of the ellipse are the
+	// eigenvectors of the covariance matrix, and the
+	// variances along them are the eigenvalues.
+	mid := (e.VarX + e.VarY) / 2
+	r := math.Hypot((e.VarX-e.VarY)/2, e.CovXY)
+	a := e.scale() * math.Sqrt(math.Max(0, mid+r))
+	b := e.scale() * math.Sqrt(math.Max(0, mid-r))
+	θ := math.Atan2(2*e.CovXY, e.VarX-e.VarY) / 2
+	sin, cos := math.Sincos(θ)
+
+	pts := make(XYs, n)
+	for i := range pts {
+		st, ct := math.Sincos(2 * math.Pi * float64(i) / float64(n))
+		pts[i].X = e.X + a*cos*ct - b*sin*st
+		pts[i].Y = e.Y + a*sin*ct + b*cos*st
+	}
+	return pts
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (e *ConfidenceEllipse) Plot(c draw.Canvas, plt *plot.Plot) {
+	if e.VarX == 0 && e.VarY == 0 {
+		return
+	}
+	trX, trY := plt.Transforms(&c)
+	outline := e.Outline(100)
+	pts := make([]draw.Point, len(outline)+1)
+	for i, p := range outline {
+		pts[i] = draw.Point{trX(p.X), trY(p.Y)}
+	}
+	pts[len(pts)-1] = pts[0]
+	if e.Color != nil {
+		c.FillPolygon(e.Color, c.ClipPolygonXY(pts[:len(outline)]))
+	}
+	c.StrokeLines(e.LineStyle, c.ClipLinesXY(pts)...)
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (e *ConfidenceEllipse) DataRange() (xmin, xmax, ymin, ymax float64) {
+	w := e.scale() * math.Sqrt(e.VarX)
+	h := e.scale() * math.Sqrt(e.VarY)
+	return e.X - w, e.X + w, e.Y - h, e.Y + h
+}
@@ -0,0 +1,48 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"testing"
+)
+
+func TestConfidenceEllipse(t *testing.T) {
+	k := math.Sqrt(-2 * math.Log(0.05))
+	for _, test := range []struct {
+		xys XYs
+
+		// a and b are the expected semi-axes.
+		a, b float64
+	}{
+		{xys: XYs{{-1, 0}, {1, 0}, {0, -1}, {0, 1}}, a: k * math.Sqrt(2.0/3), b: k * math.Sqrt(2.0/3)},
+		{xys: XYs{{0, 0}, {1, 1}, {2, 2}}, a: k * math.Sqrt(2), b: 0},
+		{xys: XYs{{1, 1}, {1, 1}}, a: 0, b: 0},
+	} {
+		e, err := NewConfidenceEllipse(test.xys, 0.95)
+		if err != nil {
+			t.Fatalf("failed to create ellipse: %v", err)
+		}
+		amax, bmin := 0.0, math.Inf(1)
+		for _, p := range e.Outline(8) {
+			if math.IsNaN(p.X) || math.IsNaN(p.Y) {
+				t.Fatalf("NaN in outline for %v", test.xys)
+			}
+			d := math.Hypot(p.X-e.X, p.Y-e.Y)
+			amax = math.Max(amax, d)
+			bmin = math.Min(bmin, d)
+		}
+		if math.Abs(amax-test.a) > 1e-12 || math.Abs(bmin-test.b) > 1e-12 {
+			t.Errorf("unexpected semi-axes for %v: got:%g,%g want:%g,%g", test.xys, amax, bmin, test.a, test.b)
+		}
+	}
+
+	if _, err := NewConfidenceEllipse(XYs{{0, 0}, {1, 1}}, 1); err == nil {
+		t.Error("expected error for confidence of one")
+	}
+	if _, err := NewConfidenceEllipse(XYs{{0, 0}}, 0.95); err == nil {
+		t.Error("expected error for a single point")
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// Fit is a least-squares polynomial fit to a set of points,
+// typically drawn as a trend line over a Scatter of them.
+type Fit struct {
+	// Coeffs are the coefficients of the polynomial,
+	// starting with the constant term.
+	Coeffs []float64
+
+	// RSquared is the coefficient of determination of
+	// the fit, the fraction of the variance of the Y values
+	// that it explains.  If the Y values are all equal then
+	// RSquared is one.
+	RSquared float64
+}
+
+// NewFit returns the least-squares fit of the polynomial of
+// the given degree to the points, a straight line if the
+// degree is one.  An error is returned if there are fewer
+// distinct X values than the number of coefficients, as when
+// the points all lie on a vertical line, or if the fit can
+// not be computed accurately.
+func NewFit(xys XYer, degree int) (Fit, error) {
+	if degree < 0 {
+		return Fit{}, errors.New("Negative fit degree")
+	}
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return Fit{}, err
+	}
+	xs := make([]float64, len(data))
+	var mean float64
+	for i, d := range data {
+		xs[i] = d.X
+		mean += d.X
+	}
+	mean /= float64(len(data))
+	sort.Float64s(xs)
+	distinct := 0
+	for i, x := range xs {
+		if i == 0 || x != xs[i-1] {
+			distinct++
+		}
+	}
+	if distinct <= degree {
+		return Fit{}, errors.New("Too few distinct X values for the fit degree")
+	}
+
+	// The fit is computed for X values centered on their
+	// mean and scaled to about unit size, which keeps the
+	// normal equations well conditioned.
+	scale := math.Max(xs[len(xs)-1]-mean, mean-xs[0])
+	if scale == 0 {
+		scale = 1
+	}
+	n := degree + 1
+	a := make([][]float64, n)
+	for i := range a {
+		a[i] = make([]float64, n+1)
+	}
+	pows := make([]float64, 2*n-1)
+	for _, d := range data {
+		t := (d.X - mean) / scale
+		p := 1.0
+		for k := range pows {
+			pows[k] = p
+			p *= t
+		}
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				a[i][j] += pows[i+j]
+			}
+			a[i][n] += pows[i] * d.Y
+		}
+	}
+	cs, ok := solve(a)
+	if !ok {
+		return Fit{}, errors.New("Fit is numerically singular")
+	}
+
+	// The polynomial in the scaled X is expanded by
+	// Horner's rule, multiplying by (x-mean)/scale.
+	f := Fit{Coeffs: make([]float64, n)}
+	for k := n - 1; k >= 0; k-- {
+		for i := n - 1; i >= 0; i-- {
+			prev := 0.0
+			if i > 0 {
+				prev = f.Coeffs[i-1]
+			}
+			f.Coeffs[i] = (prev - mean*f.Coeffs[i]) / scale
+		}
+		f.Coeffs[0] += cs[k]
+	}
+
+	var ymean float64
+	for _, d := range data {
+		ymean += d.Y
+	}
+	ymean /= float64(len(data))
+	var res, tot float64
+	for _, d := range data {
+		e := d.Y - f.Value(d.X)
+		res += e * e
+		tot += (d.Y - ymean) * (d.Y - ymean)
+	}
+	f.RSquared = 1
+	if tot > 0 {
+		f.RSquared = 1 - res/tot
+	}
+	return f, nil
+}
+
+// solve returns the solution of the linear equations given
+// by the augmented matrix a, computed in place by Gaussian
+// elimination with partial pivoting.  It returns false if
+// the equations are singular or nearly so.
+func solve(a [][]float64) ([]float64, bool) {
+	n := len(a)
+	var norm float64
+	for _, r := range a {
+		for _, v := range r[:n] {
+			norm = math.Max(norm, math.Abs(v))
+		}
+	}
+	const eps = 1e-12
+	for c := 0; c < n; c++ {
+		p := c
+		for r := c + 1; r < n; r++ {
+			if math.Abs(a[r][c]) > math.Abs(a[p][c]) {
+				p = r
+			}
+		}
+		if math.Abs(a[p][c]) <= eps*norm {
+			return nil, false
+		}
+		a[c], a[p] = a[p], a[c]
+		for r := c + 1; r < n; r++ {
+			m := a[r][c] / a[c][c]
+			for k := c; k <= n; k++ {
+				a[r][k] -= m * a[c][k]
+			}
+		}
+	}
+	x := make([]float64, n)
+	for r := n - 1; r >= 0; r-- {
+		v := a[r][n]
+		for k := r + 1; k < n; k++ {
+			v -= a[r][k] * x[k]
+		}
+		x[r] = v / a[r][r]
+	}
+	return x, true
+}
+
+// Value returns the value of the fitted polynomial at x.
+func (f Fit) Value(x float64) float64 {
+	var y float64
+	for i := len(f.Coeffs) - 1; i >= 0; i-- {
+		y = y*x + f.Coeffs[i]
+	}
+	return y
+}
+
+// NewTrendLine returns a Function that draws the least-squares
+// fit of the polynomial of the given degree to the points across
+// the whole range of the X axis, along with the Fit itself for
+// use in annotations.  The fit is computed as by NewFit.
+func NewTrendLine(xys XYer, degree int) (*Function, Fit, error) {
+	f, err := NewFit(xys, degree)
+	if err != nil {
+		return nil, Fit{}, err
+	}
+	return NewFunction(f.Value), f, nil
+}
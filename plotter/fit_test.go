@@ -0,0 +1,46 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFit(t *testing.T) {
+	for _, test := range []struct {
+		xys    XYs
+		degree int
+		coeffs []float64
+		r2     float64
+	}{
+		{xys: XYs{{0, 1}, {1, 3}, {2, 5}}, degree: 1, coeffs: []float64{1, 2}, r2: 1},
+		{xys: XYs{{1000, 2}, {1001, 3}, {1002, 6}}, degree: 2, coeffs: []float64{1000002, -2000, 1}, r2: 1},
+		{xys: XYs{{0, 0}, {1, 1}, {2, 0}, {3, 1}}, degree: 1, coeffs: []float64{0.2, 0.2}, r2: 0.2},
+		{xys: XYs{{0, 4}, {1, 4}}, degree: 1, coeffs: []float64{4, 0}, r2: 1},
+	} {
+		f, err := NewFit(test.xys, test.degree)
+		if err != nil {
+			t.Fatalf("failed to fit %v: %v", test.xys, err)
+		}
+		for i, c := range test.coeffs {
+			if math.Abs(f.Coeffs[i]-c) > 1e-6*math.Max(1, math.Abs(c)) {
+				t.Errorf("unexpected coefficients for %v: got:%v want:%v", test.xys, f.Coeffs, test.coeffs)
+				break
+			}
+		}
+		if math.Abs(f.RSquared-test.r2) > 1e-9 {
+			t.Errorf("unexpected R² for %v: got:%g want:%g", test.xys, f.RSquared, test.r2)
+		}
+	}
+
+	// Points on a vertical line have no fit.
+	if _, err := NewFit(XYs{{1, 0}, {1, 1}, {1, 2}}, 1); err == nil {
+		t.Error("expected an error for a vertical line of points")
+	}
+	if _, err := NewFit(XYs{{0, 0}, {1, 1}}, 2); err == nil {
+		t.Error("expected an error for too few points for the degree")
+	}
+}
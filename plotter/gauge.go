@@ -0,0 +1,234 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Gauge implements the Plotter interface, drawing a dial
+// with a needle pointing at a value.  The dial is a band
+// along an arc of a circle, with values increasing clockwise,
+// that is drawn as large as fits in the data area of the plot
+// with the arc centered on the top.  A Gauge does not use the
+// axes of the plot, which are typically hidden.
+type Gauge struct {
+	// Value is the value at which the needle points.
+	// Values outside of the range of the dial are
+	// shown at its ends.
+	Value float64
+
+	// Min and Max are the values at the
+	// start and the end of the dial.
+	Min, Max float64
+
+	// Sweep is the angle of the arc of the dial, in
+	// radians.  A Sweep of π gives a semicircular dial
+	// and 2π gives a full circle.
+	Sweep float64
+
+	// Thickness is the width of the band of the dial
+	// as a fraction of its radius.
+	Thickness float64
+
+	// Color is the fill color of the band.  If Color
+	// is nil then the band is not filled.
+	Color color.Color
+
+	// Zones are ranges of values whose part of the
+	// band is filled with their own colors, drawn in
+	// order over the fill of the band.
+	Zones []GaugeZone
+
+	// LineStyle is the style of the outline of the band.
+	LineStyle draw.LineStyle
+
+	// NeedleStyle is the style of the needle.  The
+	// needle's hub is a disc twice its width across.
+	NeedleStyle draw.LineStyle
+
+	// Tick is the style of the ticks drawn along the
+	// inner edge of the band.
+	Tick struct {
+		// Marker returns the ticks in the range of
+		// the dial.  If Marker is nil then no ticks
+		// are drawn.
+		Marker plot.Ticker
+
+		// Label is the style of the labels of the
+		// major ticks.
+		Label draw.TextStyle
+
+		// LineStyle is the style of the tick marks.
+		draw.LineStyle
+
+		// Length is the length of a major tick mark.
+		// Minor tick marks are half as long, and ticks
+		// with a LengthScale are scaled by it.
+		Length vg.Length
+	}
+}
+
+// GaugeZone is a range of the values of a Gauge
+// that is filled with a color.
+type GaugeZone struct {
+	// Min and Max are the range of the zone.
+	Min, Max float64
+
+	// Color is the fill color of the zone.
+	Color color.Color
+}
+
+// NewGauge returns a new semicircular Gauge for the
+// range min to max with its needle at value, and with
+// ticks given by plot.DefaultTicks.
+func NewGauge(value, min, max float64) (*Gauge, error) {
+	if !(min < max) {
+		return nil, errors.New("Gauge range is empty or inverted")
+	}
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	g := &Gauge{
+		Value:     value,
+		Min:       min,
+		Max:       max,
+		Sweep:     math.Pi,
+		Thickness: 0.2,
+		Color:     color.Gray{Y: 0xd3},
+		LineStyle: DefaultLineStyle,
+		NeedleStyle: draw.LineStyle{
+			Color: color.Black,
+			Width: vg.Points(2),
+		},
+	}
+	g.Tick.Marker = plot.DefaultTicks{}
+	g.Tick.Label = draw.TextStyle{Color: color.Black, Font: fnt}
+	g.Tick.LineStyle = DefaultLineStyle
+	g.Tick.Length = vg.Points(6)
+	return g, nil
+}
+
+// angle returns the angle of the value v around the
+// dial, counter-clockwise from the positive X direction.
+func (g *Gauge) angle(v float64) float64 {
+	f := (v - g.Min) / (g.Max - g.Min)
+	f = math.Max(0, math.Min(1, f))
+	return math.Pi/2 + g.Sweep/2 - f*g.Sweep
+}
+
+// dial returns the center and the outer radius
+// of the largest dial that fits in the canvas.
+func (g *Gauge) dial(c draw.Canvas) (center draw.Point, r vg.Length) {
+	// The extents of the arc of the unit circle and its
+	// center, which is the pivot of the needle.
+	xmin, xmax, ymin, ymax := 0.0, 0.0, 0.0, 0.0
+	extend := func(θ float64) {
+		xmin = math.Min(xmin, math.Cos(θ))
+		xmax = math.Max(xmax, math.Cos(θ))
+		ymin = math.Min(ymin, math.Sin(θ))
+		ymax = math.Max(ymax, math.Sin(θ))
+	}
+	start, end := g.angle(g.Max), g.angle(g.Min)
+	extend(start)
+	extend(end)
+	for θ := math.Ceil(start/(math.Pi/2)) * math.Pi / 2; θ < end; θ += math.Pi / 2 {
+		extend(θ)
+	}
+
+	size := c.Size()
+	r = vg.Length(math.Min(float64(size.X)/(xmax-xmin), float64(size.Y)/(ymax-ymin)))
+	// The dial is centered in the canvas.
+	center = draw.Point{
+		X: c.Center().X - r*vg.Length(xmin+xmax)/2,
+		Y: c.Center().Y - r*vg.Length(ymin+ymax)/2,
+	}
+	return center, r
+}
+
+// band returns the path of the part of the band of the dial
+// spanning the values from min to max.
+func (g *Gauge) band(center draw.Point, r vg.Length, min, max float64) vg.Path {
+	start, end := g.angle(max), g.angle(min)
+	inner := r * vg.Length(1-g.Thickness)
+	var p vg.Path
+	p.Move(center.X+r*vg.Length(math.Cos(start)), center.Y+r*vg.Length(math.Sin(start)))
+	p.Arc(center.X, center.Y, r, start, end-start)
+	p.Line(center.X+inner*vg.Length(math.Cos(end)), center.Y+inner*vg.Length(math.Sin(end)))
+	p.Arc(center.X, center.Y, inner, end, start-end)
+	p.Close()
+	return p
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (g *Gauge) Plot(c draw.Canvas, plt *plot.Plot) {
+	center, r := g.dial(c)
+	if r <= 0 {
+		return
+	}
+	if g.Color != nil {
+		c.SetColor(g.Color)
+		c.Fill(g.band(center, r, g.Min, g.Max))
+	}
+	for _, z := range g.Zones {
+		if z.Color == nil {
+			continue
+		}
+		c.SetColor(z.Color)
+		c.Fill(g.band(center, r, z.Min, z.Max))
+	}
+	if g.LineStyle.Color != nil && g.LineStyle.Width > 0 {
+		c.SetLineStyle(g.LineStyle)
+		c.Stroke(g.band(center, r, g.Min, g.Max))
+	}
+
+	point := func(θ float64, r vg.Length) draw.Point {
+		return draw.Point{
+			X: center.X + r*vg.Length(math.Cos(θ)),
+			Y: center.Y + r*vg.Length(math.Sin(θ)),
+		}
+	}
+	if g.Tick.Marker != nil {
+		inner := r * vg.Length(1-g.Thickness)
+		for _, t := range g.Tick.Marker.Ticks(g.Min, g.Max) {
+			θ := g.angle(t.Value)
+			l := g.Tick.Length
+			switch {
+			case t.LengthScale > 0:
+				l *= vg.Length(math.Min(t.LengthScale, 1))
+			case t.IsMinor():
+				l /= 2
+			}
+			p0, p1 := point(θ, inner), point(θ, inner-l)
+			c.StrokeLine2(g.Tick.LineStyle, p0.X, p0.Y, p1.X, p1.Y)
+			if !t.HasLabel() {
+				continue
+			}
+			// The label is placed inside the tick mark, with
+			// the nearest point of its bounding box on the
+			// tick's radius.
+			w := g.Tick.Label.Width(t.Label)
+			h := g.Tick.Label.Height(t.Label)
+			d := inner - g.Tick.Length - g.Tick.Length/2 -
+				vg.Length(math.Abs(math.Cos(θ)))*w/2 - vg.Length(math.Abs(math.Sin(θ)))*h/2
+			p := point(θ, d)
+			c.FillText(g.Tick.Label, p.X, p.Y, -0.5, -0.5, t.Label)
+		}
+	}
+
+	tip := point(g.angle(g.Value), r*vg.Length(1-g.Thickness/2))
+	c.StrokeLine2(g.NeedleStyle, center.X, center.Y, tip.X, tip.Y)
+	if g.NeedleStyle.Color != nil {
+		c.SetColor(g.NeedleStyle.Color)
+		c.Fill(draw.CirclePath(center, g.NeedleStyle.Width))
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+func TestGauge(t *testing.T) {
+	g, err := NewGauge(150, 0, 100)
+	if err != nil {
+		t.Fatalf("failed to create gauge: %v", err)
+	}
+	for _, test := range []struct {
+		v, want float64
+	}{
+		{v: 0, want: math.Pi},
+		{v: 50, want: math.Pi / 2},
+		{v: 100, want: 0},
+		{v: -10, want: math.Pi},
+		{v: 150, want: 0},
+	} {
+		if got := g.angle(test.v); math.Abs(got-test.want) > 1e-12 {
+			t.Errorf("unexpected angle of %g: got:%g want:%g", test.v, got, test.want)
+		}
+	}
+
+	// A semicircular dial sits on the bottom of the canvas.
+	r := recorder.New(72)
+	c := draw.NewCanvas(r, 300, 100)
+	center, radius := g.dial(c)
+	if center != (draw.Point{X: 150, Y: 0}) || radius != 100 {
+		t.Errorf("unexpected dial: got center:%v radius:%v want center:{150 0} radius:100", center, radius)
+	}
+	g.Sweep = 2 * math.Pi
+	center, radius = g.dial(c)
+	if center != (draw.Point{X: 150, Y: 50}) || radius != 50 {
+		t.Errorf("unexpected full dial: got center:%v radius:%v want center:{150 50} radius:50", center, radius)
+	}
+
+	g.Zones = []GaugeZone{
+		{Min: 80, Max: 100, Color: color.RGBA{R: 255, A: 255}},
+		{Min: 60, Max: 80, Color: color.RGBA{R: 255, G: 255, A: 255}},
+	}
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	g.Plot(c, p)
+	var fills int
+	for _, a := range r.Actions {
+		if _, ok := a.(*recorder.Fill); ok {
+			fills++
+		}
+	}
+	// The band, the two zones and the hub are filled.
+	if fills != 4 {
+		t.Errorf("unexpected number of fills: got:%d want:4", fills)
+	}
+
+	if _, err := NewGauge(0, 1, 1); err == nil {
+		t.Error("expected error for empty range")
+	}
+}
@@ -21,13 +21,26 @@ var (
 )
 
 // Grid implements the plot.Plotter interface, drawing
-// a set of grid lines at the major tick marks.
+// a set of grid lines at the major tick marks, and
+// optionally at the minor tick marks.
 type Grid struct {
 	// Vertical is the style of the vertical lines.
 	Vertical draw.LineStyle
 
 	// Horizontal is the style of the horizontal lines.
 	Horizontal draw.LineStyle
+
+	// Minor specifies whether grid lines are also
+	// drawn at the minor tick marks.
+	Minor bool
+
+	// MinorVertical and MinorHorizontal are the
+	// styles of the minor grid lines.  If the Color of
+	// a minor style is nil then the lines are drawn
+	// in a lighter shade of the color of the major
+	// lines, and if its Width is zero then they have
+	// the width of the major lines.
+	MinorVertical, MinorHorizontal draw.LineStyle
 }
 
 // NewGrid returns a new grid with both vertical and
@@ -43,26 +56,66 @@ func NewGrid() *Grid {
 func (g *Grid) Plot(c draw.Canvas, plt *plot.Plot) {
 	trX, trY := plt.Transforms(&c)
 
-	if g.Vertical.Color == nil {
-		goto horiz
+	if g.Vertical.Color != nil {
+		var major, minor [][]draw.Point
+		for _, tk := range plt.X.Tick.Marker.Ticks(plt.X.Min, plt.X.Max) {
+			x := trX(tk.Value)
+			if !c.ContainsX(x) {
+				continue
+			}
+			line := []draw.Point{{x, c.Min.Y}, {x, c.Min.Y + c.Size().Y}}
+			if tk.IsMinor() {
+				minor = append(minor, line)
+				continue
+			}
+			major = append(major, line)
+		}
+		if g.Minor {
+			c.StrokeLines(minorStyle(g.MinorVertical, g.Vertical), minor...)
+		}
+		c.StrokeLines(g.Vertical, major...)
 	}
-	for _, tk := range plt.X.Tick.Marker.Ticks(plt.X.Min, plt.X.Max) {
-		if tk.IsMinor() {
-			continue
+
+	if g.Horizontal.Color != nil {
+		var major, minor [][]draw.Point
+		for _, tk := range plt.Y.Tick.Marker.Ticks(plt.Y.Min, plt.Y.Max) {
+			y := trY(tk.Value)
+			if !c.ContainsY(y) {
+				continue
+			}
+			line := []draw.Point{{c.Min.X, y}, {c.Min.X + c.Size().X, y}}
+			if tk.IsMinor() {
+				minor = append(minor, line)
+				continue
+			}
+			major = append(major, line)
 		}
-		x := trX(tk.Value)
-		c.StrokeLine2(g.Vertical, x, c.Min.Y, x, c.Min.Y+c.Size().Y)
+		if g.Minor {
+			c.StrokeLines(minorStyle(g.MinorHorizontal, g.Horizontal), minor...)
+		}
+		c.StrokeLines(g.Horizontal, major...)
 	}
+}
 
-horiz:
-	if g.Horizontal.Color == nil {
-		return
+// minorStyle returns the style of minor grid lines,
+// filling in the color and width of sty from the
+// style of the major grid lines.
+func minorStyle(sty, major draw.LineStyle) draw.LineStyle {
+	if sty.Color == nil {
+		sty.Color = lighter(major.Color)
 	}
-	for _, tk := range plt.Y.Tick.Marker.Ticks(plt.Y.Min, plt.Y.Max) {
-		if tk.IsMinor() {
-			continue
-		}
-		y := trY(tk.Value)
-		c.StrokeLine2(g.Horizontal, c.Min.X, y, c.Min.X+c.Size().X, y)
+	if sty.Width == 0 {
+		sty.Width = major.Width
 	}
+	return sty
+}
+
+// lighter returns the color half way
+// between c and white.
+func lighter(c color.Color) color.Color {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	n.R += (255 - n.R) / 2
+	n.G += (255 - n.G) / 2
+	n.B += (255 - n.B) / 2
+	return n
 }
@@ -0,0 +1,67 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+func TestGridMinor(t *testing.T) {
+	for _, minor := range []bool{false, true} {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("failed to create plot: %v", err)
+		}
+		p.HideAxes()
+		p.Y.Scale = plot.LogScale{}
+		p.Y.Tick.Marker = plot.LogTicks{}
+		p.X.Min, p.X.Max = 0, 1
+		p.Y.Min, p.Y.Max = 1, 100
+
+		g := NewGrid()
+		g.Vertical.Color = nil
+		g.Minor = minor
+		p.Add(g)
+
+		r := recorder.New(72)
+		p.Draw(draw.NewCanvas(r, 100, 100))
+		var colors []color.Color
+		var lines []int
+		for _, a := range r.Actions {
+			switch a := a.(type) {
+			case *recorder.SetColor:
+				colors = append(colors, a.Color)
+			case *recorder.Stroke:
+				lines = append(lines, len(a.Path)/2)
+			}
+		}
+
+		// The grid lines are at 1, 10 and 100, and the
+		// minor lines at 2-9 and 20-90.
+		want := []int{3}
+		if minor {
+			want = []int{16, 3}
+		}
+		if len(lines) != len(want) {
+			t.Fatalf("unexpected strokes with minor=%t: got:%v want:%v", minor, lines, want)
+		}
+		for i := range want {
+			if lines[i] != want[i] {
+				t.Errorf("unexpected number of lines in stroke %d with minor=%t: got:%d want:%d", i, minor, lines[i], want[i])
+			}
+		}
+		if minor {
+			got := colors[len(colors)-2]
+			if want := lighter(DefaultGridLineStyle.Color); got != want {
+				t.Errorf("unexpected minor grid color: got:%v want:%v", got, want)
+			}
+		}
+	}
+}
@@ -49,9 +49,24 @@ type HeatMap struct {
 	Underflow color.Color
 	Overflow  color.Color
 
+	// NaNColor is the color used to fill heat map
+	// elements whose values are NaN or infinite,
+	// marking them as missing data.  If NaNColor is
+	// nil then those elements are not drawn, so the
+	// background shows through.
+	NaNColor color.Color
+
 	// Min and Max define the dynamic range of the
 	// heat map.
 	Min, Max float64
+
+	// Log specifies whether values are mapped to
+	// colors on a logarithmic scale.  If Log is true
+	// then Max must be positive, a non-positive Min
+	// is replaced by the least positive value of the
+	// grid, and non-positive values are treated as
+	// being below Min.
+	Log bool
 }
 
 // NewHeatMap creates as new heat map plotter for the given data,
@@ -92,13 +107,7 @@ func NewHeatMap(g GridXYZ, p palette.Palette) *HeatMap {
 
 // Plot implements the Plot method of the plot.Plotter interface.
 func (h *HeatMap) Plot(c draw.Canvas, plt *plot.Plot) {
-	pal := h.Palette.Colors()
-	if len(pal) == 0 {
-		panic("heatmap: empty palette")
-	}
-	// ps scales the palette uniformly across the data range.
-	ps := float64(len(pal)-1) / (h.Max - h.Min)
-
+	fill := h.colors()
 	trX, trY := plt.Transforms(&c)
 
 	var pa vg.Path
@@ -119,8 +128,8 @@ func (h *HeatMap) Plot(c draw.Canvas, plt *plot.Plot) {
 		}
 
 		for j := 0; j < rows; j++ {
-			v := h.GridXYZ.Z(i, j)
-			if math.IsNaN(v) || math.IsInf(v, 0) {
+			col := fill(h.GridXYZ.Z(i, j))
+			if col == nil {
 				continue
 			}
 
@@ -152,21 +161,64 @@ func (h *HeatMap) Plot(c draw.Canvas, plt *plot.Plot) {
 			pa.Line(x, dy)
 			pa.Close()
 
-			var col color.Color
-			switch {
-			case v < h.Min:
-				col = h.Underflow
-			case v > h.Max:
-				col = h.Overflow
-			default:
-				col = pal[int((v-h.Min)*ps+0.5)] // Apply palette scaling.
+			c.SetColor(col)
+			c.Fill(pa)
+		}
+	}
+}
+
+// colors returns the function giving the fill color of
+// a heat map element with the value v, or nil if the
+// element is not drawn.
+func (h *HeatMap) colors() func(v float64) color.Color {
+	pal := h.Palette.Colors()
+	if len(pal) == 0 {
+		panic("heatmap: empty palette")
+	}
+	min, max := h.Min, h.Max
+	if h.Log {
+		if !(min > 0) {
+			min = h.minPositive()
+		}
+		min, max = math.Log(min), math.Log(max)
+	}
+	// ps scales the palette uniformly across the data range.
+	ps := float64(len(pal)-1) / (max - min)
+
+	return func(v float64) color.Color {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return h.NaNColor
+		}
+		if h.Log {
+			if v > 0 {
+				v = math.Log(v)
+			} else {
+				v = math.Inf(-1)
 			}
-			if col != nil {
-				c.SetColor(col)
-				c.Fill(pa)
+		}
+		switch {
+		case v < min:
+			return h.Underflow
+		case v > max:
+			return h.Overflow
+		}
+		return pal[int((v-min)*ps+0.5)] // Apply palette scaling.
+	}
+}
+
+// minPositive returns the least positive
+// finite value of the heat map's grid.
+func (h *HeatMap) minPositive() float64 {
+	min := math.Inf(1)
+	c, r := h.GridXYZ.Dims()
+	for i := 0; i < c; i++ {
+		for j := 0; j < r; j++ {
+			if v := h.GridXYZ.Z(i, j); v > 0 && v < min {
+				min = v
 			}
 		}
 	}
+	return min
 }
 
 // DataRange implements the DataRange method
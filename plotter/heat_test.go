@@ -0,0 +1,94 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+func TestHeatMapNaNColor(t *testing.T) {
+	g := unitGrid{mat64.NewDense(2, 2, []float64{1, math.NaN(), math.Inf(1), 2})}
+	gray := color.Gray{Y: 0xd3}
+	for _, test := range []struct {
+		nan         color.Color
+		fills, nans int
+	}{
+		{nan: nil, fills: 2, nans: 0},
+		{nan: gray, fills: 4, nans: 2},
+	} {
+		h := NewHeatMap(g, palette.Heat(4, 1))
+		h.NaNColor = test.nan
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("failed to create plot: %v", err)
+		}
+		p.HideAxes()
+		p.BackgroundColor = nil
+		p.Add(h)
+
+		r := recorder.New(72)
+		p.Draw(draw.NewCanvas(r, 100, 100))
+		var fills, nans int
+		var cur color.Color
+		for _, a := range r.Actions {
+			switch a := a.(type) {
+			case *recorder.SetColor:
+				cur = a.Color
+			case *recorder.Fill:
+				fills++
+				if cur == gray {
+					nans++
+				}
+			}
+		}
+		if fills != test.fills || nans != test.nans {
+			t.Errorf("unexpected fills for NaNColor %v: got %d fills, %d missing; want %d, %d",
+				test.nan, fills, nans, test.fills, test.nans)
+		}
+	}
+}
+
+func TestHeatMapLog(t *testing.T) {
+	g := unitGrid{mat64.NewDense(2, 3, []float64{1, 10, 100, 1000, 0, -1})}
+	pal := palette.Heat(4, 1).Colors()
+	h := NewHeatMap(g, palette.Heat(4, 1))
+	h.Log = true
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.HideAxes()
+	p.BackgroundColor = nil
+	p.Add(h)
+
+	r := recorder.New(72)
+	p.Draw(draw.NewCanvas(r, 100, 100))
+	got := make(map[color.Color]int)
+	var cur color.Color
+	for _, a := range r.Actions {
+		switch a := a.(type) {
+		case *recorder.SetColor:
+			cur = a.Color
+		case *recorder.Fill:
+			got[cur]++
+		}
+	}
+	if len(got) != len(pal) {
+		t.Errorf("unexpected number of colors: got:%d want:%d", len(got), len(pal))
+	}
+	for _, c := range pal {
+		if got[c] != 1 {
+			t.Errorf("unexpected fills of color %v: got:%d want:1", c, got[c])
+		}
+	}
+}
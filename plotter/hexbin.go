@@ -0,0 +1,211 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// HexBin implements the Plotter interface, drawing
+// a two dimensional histogram of a set of points
+// binned into a grid of hexagonal cells.  Each cell
+// is filled with the color of its total weight.
+type HexBin struct {
+	// Bins are the cells of the grid that
+	// contain at least one point.
+	Bins []HexBinBin
+
+	// Size is the distance from the center of
+	// each cell to its vertices in data coordinates.
+	// The cells have a vertex at the top and bottom.
+	Size float64
+
+	// Palette is the color palette used to fill
+	// the cells.  Palette must not be nil or return
+	// a zero length []color.Color.
+	Palette palette.Palette
+
+	// Min and Max define the range of weights
+	// represented by the palette.  Weights outside
+	// of the range are given the first or last color.
+	Min, Max float64
+
+	// Log specifies whether the weights are mapped
+	// to colors on a logarithmic scale.  Min and Max
+	// must be positive if Log is true, and cells whose
+	// weights are not positive are not drawn.
+	Log bool
+}
+
+// HexBinBin is a single cell of a HexBin.
+type HexBinBin struct {
+	// X and Y are the center of the cell.
+	X, Y float64
+
+	// Weight is the total weight of the points
+	// in the cell.
+	Weight float64
+}
+
+// NewHexBin returns a new HexBin with cells of the given
+// size that counts the number of points in each cell.
+func NewHexBin(xys XYer, size float64, p palette.Palette) (*HexBin, error) {
+	return NewWeightedHexBin(unitWeights{xys}, size, p)
+}
+
+// NewWeightedHexBin returns a new HexBin with cells of the
+// given size, where the weight of each cell is the sum of
+// the Z values of the points in the cell.
+func NewWeightedHexBin(xyz XYZer, size float64, p palette.Palette) (*HexBin, error) {
+	if !(size > 0) {
+		return nil, errors.New("HexBin with non-positive size")
+	}
+	data, err := CopyXYZs(xyz)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, ErrNoData
+	}
+
+	h := &HexBin{
+		Size:    size,
+		Palette: p,
+		Min:     math.Inf(1),
+		Max:     math.Inf(-1),
+	}
+	index := make(map[[2]int]int)
+	for _, d := range data {
+		k := h.cell(d.X, d.Y)
+		i, ok := index[k]
+		if !ok {
+			i = len(h.Bins)
+			index[k] = i
+			x, y := h.center(k)
+			h.Bins = append(h.Bins, HexBinBin{X: x, Y: y})
+		}
+		h.Bins[i].Weight += d.Z
+	}
+	for _, b := range h.Bins {
+		h.Min = math.Min(h.Min, b.Weight)
+		h.Max = math.Max(h.Max, b.Weight)
+	}
+	return h, nil
+}
+
+// unitWeights is an XYZer giving each
+// of the points of an XYer a weight of 1.
+type unitWeights struct{ XYer }
+
+func (u unitWeights) XYZ(i int) (float64, float64, float64) {
+	x, y := u.XY(i)
+	return x, y, 1
+}
+
+// cell returns the column and row of the
+// cell containing the point x, y.
+func (h *HexBin) cell(x, y float64) [2]int {
+	w, dy := h.spacing()
+
+	// The centers of the cells in the even and odd rows
+	// each form a rectangular grid.  The point is in the
+	// cell of the nearer of its nearest centers in each.
+	even := [2]int{int(math.Floor(x/w + 0.5)), 2 * int(math.Floor(y/(2*dy)+0.5))}
+	odd := [2]int{int(math.Floor(x / w)), 2*int(math.Floor(y/(2*dy))) + 1}
+	ex, ey := h.center(even)
+	ox, oy := h.center(odd)
+	if math.Hypot(x-ex, y-ey) <= math.Hypot(x-ox, y-oy) {
+		return even
+	}
+	return odd
+}
+
+// center returns the center of the cell
+// at the given column and row.
+func (h *HexBin) center(k [2]int) (x, y float64) {
+	w, dy := h.spacing()
+	x = float64(k[0]) * w
+	if k[1]%2 != 0 {
+		x += w / 2
+	}
+	return x, float64(k[1]) * dy
+}
+
+// spacing returns the horizontal distance between
+// the centers of adjacent cells in a row and the
+// vertical distance between adjacent rows.
+func (h *HexBin) spacing() (w, dy float64) {
+	return math.Sqrt(3) * h.Size, 1.5 * h.Size
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (h *HexBin) Plot(c draw.Canvas, plt *plot.Plot) {
+	pal := h.Palette.Colors()
+	if len(pal) == 0 {
+		panic("hexbin: empty palette")
+	}
+	min, max := h.Min, h.Max
+	if h.Log {
+		min, max = math.Log(min), math.Log(max)
+	}
+	// ps scales the palette uniformly across the range.
+	ps := 0.0
+	if max > min {
+		ps = float64(len(pal)-1) / (max - min)
+	}
+
+	trX, trY := plt.Transforms(&c)
+	pts := make([]draw.Point, 6)
+	for _, b := range h.Bins {
+		for i := range pts {
+			θ := math.Pi/6 + float64(i)*math.Pi/3
+			pts[i] = draw.Point{
+				trX(b.X + h.Size*math.Cos(θ)),
+				trY(b.Y + h.Size*math.Sin(θ)),
+			}
+		}
+		v := b.Weight
+		if h.Log {
+			if !(v > 0) {
+				continue
+			}
+			v = math.Log(v)
+		}
+		if math.IsNaN(v) {
+			continue
+		}
+		var col color.Color
+		switch {
+		case v <= min:
+			col = pal[0]
+		case v >= max:
+			col = pal[len(pal)-1]
+		default:
+			col = pal[int((v-min)*ps+0.5)]
+		}
+		c.FillPolygon(col, c.ClipPolygonXY(pts))
+	}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (h *HexBin) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax = math.Inf(1), math.Inf(-1)
+	ymin, ymax = math.Inf(1), math.Inf(-1)
+	w, _ := h.spacing()
+	for _, b := range h.Bins {
+		xmin = math.Min(xmin, b.X-w/2)
+		xmax = math.Max(xmax, b.X+w/2)
+		ymin = math.Min(ymin, b.Y-h.Size)
+		ymax = math.Max(ymax, b.Y+h.Size)
+	}
+	return xmin, xmax, ymin, ymax
+}
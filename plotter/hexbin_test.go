@@ -0,0 +1,86 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+func TestHexBin(t *testing.T) {
+	const size = 1.0
+	rnd := rand.New(rand.NewSource(1))
+	xys := make(XYs, 1000)
+	for i := range xys {
+		xys[i].X = 10 * rnd.Float64()
+		xys[i].Y = 10 * rnd.Float64()
+	}
+	h, err := NewHexBin(xys, size, palette.Heat(10, 1))
+	if err != nil {
+		t.Fatalf("failed to create hex bin: %v", err)
+	}
+
+	var total float64
+	for _, b := range h.Bins {
+		total += b.Weight
+	}
+	if total != float64(len(xys)) {
+		t.Errorf("unexpected total weight: got:%g want:%d", total, len(xys))
+	}
+
+	// Each point must be in the cell with the nearest center.
+	for _, p := range xys {
+		k := h.cell(p.X, p.Y)
+		x, y := h.center(k)
+		d := math.Hypot(p.X-x, p.Y-y)
+		if d > size {
+			t.Errorf("point %v is outside of its cell centered at (%g,%g)", p, x, y)
+		}
+		for _, b := range h.Bins {
+			if math.Hypot(p.X-b.X, p.Y-b.Y) < d-1e-12 {
+				t.Errorf("point %v is nearer to (%g,%g) than to its cell centered at (%g,%g)", p, b.X, b.Y, x, y)
+				break
+			}
+		}
+	}
+
+	if _, err := NewHexBin(xys, 0, palette.Heat(10, 1)); err == nil {
+		t.Error("expected error for zero size")
+	}
+}
+
+func TestHexBinLog(t *testing.T) {
+	h, err := NewWeightedHexBin(XYZs{{0, 0, 0}, {5, 0, -1}, {10, 0, 5}, {15, 0, 10}}, 1, palette.Heat(10, 1))
+	if err != nil {
+		t.Fatalf("failed to create hex bin: %v", err)
+	}
+	h.Bins = append(h.Bins, HexBinBin{X: 20, Weight: math.NaN()})
+	h.Log = true
+	h.Min, h.Max = 1, 10
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.X.Min, p.X.Max = -1, 21
+	p.Y.Min, p.Y.Max = -1, 1
+	r := recorder.New(72)
+	h.Plot(draw.NewCanvas(r, 100, 100), p)
+	var fills int
+	for _, a := range r.Actions {
+		if _, ok := a.(*recorder.Fill); ok {
+			fills++
+		}
+	}
+	if fills != 2 {
+		t.Errorf("unexpected number of cells drawn: got:%d want:2", fills)
+	}
+}
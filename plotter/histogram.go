@@ -5,7 +5,6 @@
 package plotter
 
 import (
-	"errors"
 	"fmt"
 	"image/color"
 	"math"
@@ -40,12 +39,11 @@ type Histogram struct {
 // Each y value is assumed to be the frequency
 // count for the corresponding x.
 //
-// If the number of bins is non-positive than
-// a reasonable default is used.
+// If the number of bins is non-positive then about
+// as many bins as the square root of the sum of the
+// y values are used, with a round width given by
+// plot.NiceStep and edges at multiples of the width.
 func NewHistogram(xy XYer, n int) (*Histogram, error) {
-	if n <= 0 {
-		return nil, errors.New("Histogram with non-positive number of bins")
-	}
 	bins, width := binPoints(xy, n)
 	return &Histogram{
 		Bins:      bins,
@@ -147,33 +145,41 @@ func (h *Histogram) Thumbnail(c *draw.Canvas) {
 //
 // If the given number of bins is not positive
 // then a reasonable default is used.  The
-// default is the square root of the sum of
-// the y values.
+// default is about the square root of the sum
+// of the y values, with the width rounded to
+// a nice step.
 func binPoints(xys XYer, n int) ([]HistogramBin, float64) {
 	xmin, xmax := Range(XValues{xys})
+	start := xmin
+	w := (xmax - xmin) / float64(n)
 	if n <= 0 {
 		m := 0.0
 		for i := 0; i < xys.Len(); i++ {
 			_, y := xys.XY(i)
 			m += math.Max(y, 1.0)
 		}
-		n = int(math.Ceil(math.Sqrt(m)))
+		w = plot.NiceStep((xmax - xmin) / math.Ceil(math.Sqrt(m)))
+		start = math.Floor(xmin/w) * w
+		n = int(math.Ceil((xmax - start) / w))
+		if start+float64(n)*w < xmax {
+			n++
+		}
 	}
 	if n < 1 || xmax <= xmin {
 		n = 1
+		start = xmin
+		w = xmax - xmin
 	}
 
 	bins := make([]HistogramBin, n)
-
-	w := (xmax - xmin) / float64(n)
 	for i := range bins {
-		bins[i].Min = xmin + float64(i)*w
-		bins[i].Max = xmin + float64(i+1)*w
+		bins[i].Min = start + float64(i)*w
+		bins[i].Max = start + float64(i+1)*w
 	}
 
 	for i := 0; i < xys.Len(); i++ {
 		x, y := xys.XY(i)
-		bin := int((x - xmin) / w)
+		bin := int((x - start) / w)
 		if x == xmax {
 			bin = n - 1
 		}
@@ -0,0 +1,34 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import "testing"
+
+func TestHistogramAutoBins(t *testing.T) {
+	// The 25 values give about 5 bins of width 0.196,
+	// which is rounded to 0.2 with edges at multiples
+	// of 0.2.
+	vs := make(Values, 25)
+	for i := range vs {
+		vs[i] = 0.01 + 0.98*float64(i)/24
+	}
+	h, err := NewHist(vs, 0)
+	if err != nil {
+		t.Fatalf("failed to create histogram: %v", err)
+	}
+	if h.Width != 0.2 || len(h.Bins) != 5 {
+		t.Fatalf("unexpected bins: width:%g n:%d want width:0.2 n:5", h.Width, len(h.Bins))
+	}
+	if h.Bins[0].Min != 0 {
+		t.Errorf("unexpected first bin edge: got:%g want:0", h.Bins[0].Min)
+	}
+	var sum float64
+	for _, b := range h.Bins {
+		sum += b.Weight
+	}
+	if sum != 25 {
+		t.Errorf("unexpected total weight: got:%g want:25", sum)
+	}
+}
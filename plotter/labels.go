@@ -44,8 +44,43 @@ type Labels struct {
 	// XOffset and YOffset are added directly to the final
 	// label X and Y location respectively.
 	XOffset, YOffset vg.Length
+
+	// Overlap specifies how labels that would overlap
+	// previously drawn labels are handled.  The default,
+	// AllowOverlap, draws every label where it is given.
+	Overlap LabelOverlap
+
+	// LeaderStyle is the style of the lines drawn from
+	// each point to its label when the label is moved
+	// away from its given position to avoid an overlap.
+	// If its Color is nil or its Width is not positive
+	// then no leader lines are drawn.
+	LeaderStyle draw.LineStyle
 }
 
+// LabelOverlap specifies how Labels handles
+// labels that overlap each other.
+type LabelOverlap int
+
+const (
+	// AllowOverlap draws all labels at their
+	// given positions, whether or not they overlap.
+	AllowOverlap LabelOverlap = iota
+
+	// MoveOverlap moves each label that overlaps a
+	// label drawn before it to the first of a few
+	// positions around its point, nearest first, at
+	// which it overlaps no drawn label and fits in the
+	// data area.  If there is no such position then the
+	// label is drawn where it is given.
+	MoveOverlap
+
+	// DropOverlap moves labels as MoveOverlap does
+	// but does not draw those that can not be moved
+	// to a position free of overlaps.
+	DropOverlap
+)
+
 // NewLabels returns a new Labels using the DefaultFont and
 // the DefaultFontSize.
 func NewLabels(d interface {
@@ -81,18 +116,87 @@ func NewLabels(d interface {
 // Plot implements the Plotter interface, drawing labels.
 func (l *Labels) Plot(c draw.Canvas, p *plot.Plot) {
 	trX, trY := p.Transforms(&c)
+	var placed []draw.Rectangle
 	for i, label := range l.Labels {
-		x := trX(l.XYs[i].X)
-		y := trY(l.XYs[i].Y)
-		if !c.Contains(draw.Point{x, y}) {
+		pt := draw.Point{trX(l.XYs[i].X), trY(l.XYs[i].Y)}
+		if !c.Contains(pt) {
 			continue
 		}
-		x += l.XOffset
-		y += l.YOffset
-		c.FillText(l.TextStyle, x, y, l.XAlign, l.YAlign, label)
+		w, h := l.Width(label), l.Height(label)
+		min := draw.Point{
+			X: pt.X + l.XOffset + w*vg.Length(l.XAlign),
+			Y: pt.Y + l.YOffset + h*vg.Length(l.YAlign),
+		}
+		box := draw.Rectangle{Min: min, Max: draw.Point{min.X + w, min.Y + h}}
+		moved := false
+		if l.Overlap != AllowOverlap && overlapsAny(box, placed) {
+			for _, cand := range labelCandidates(pt, w, h, l.Font.Size/4) {
+				if within(cand, c.Rectangle) && !overlapsAny(cand, placed) {
+					box, moved = cand, true
+					break
+				}
+			}
+			if !moved && l.Overlap == DropOverlap {
+				continue
+			}
+		}
+		placed = append(placed, box)
+		if moved {
+			if l.LeaderStyle.Color != nil && l.LeaderStyle.Width > 0 {
+				// The leader ends at the nearest
+				// point of the label's box.
+				x := clampLength(pt.X, box.Min.X, box.Max.X)
+				y := clampLength(pt.Y, box.Min.Y, box.Max.Y)
+				c.StrokeLine2(l.LeaderStyle, pt.X, pt.Y, x, y)
+			}
+			c.FillText(l.TextStyle, box.Min.X, box.Min.Y, 0, 0, label)
+			continue
+		}
+		c.FillText(l.TextStyle, pt.X+l.XOffset, pt.Y+l.YOffset, l.XAlign, l.YAlign, label)
 	}
 }
 
+// labelCandidates returns the boxes of a w×h label at the
+// positions around the point pt that are tried in order to
+// avoid overlaps: to the right, left, above and below the
+// point, then diagonally, each first at the distance gap
+// from the point and then further away.
+func labelCandidates(pt draw.Point, w, h, gap vg.Length) []draw.Rectangle {
+	var cands []draw.Rectangle
+	for _, d := range []vg.Length{gap, gap + h} {
+		for _, off := range []draw.Point{
+			{d, -h / 2},
+			{-d - w, -h / 2},
+			{-w / 2, d},
+			{-w / 2, -d - h},
+			{d, d},
+			{-d - w, d},
+			{d, -d - h},
+			{-d - w, -d - h},
+		} {
+			min := draw.Point{pt.X + off.X, pt.Y + off.Y}
+			cands = append(cands, draw.Rectangle{Min: min, Max: draw.Point{min.X + w, min.Y + h}})
+		}
+	}
+	return cands
+}
+
+// overlapsAny returns whether r overlaps any of rs.
+func overlapsAny(r draw.Rectangle, rs []draw.Rectangle) bool {
+	for _, s := range rs {
+		if r.Min.X < s.Max.X && s.Min.X < r.Max.X && r.Min.Y < s.Max.Y && s.Min.Y < r.Max.Y {
+			return true
+		}
+	}
+	return false
+}
+
+// within returns whether r is inside of bounds.
+func within(r, bounds draw.Rectangle) bool {
+	return r.Min.X >= bounds.Min.X && r.Max.X <= bounds.Max.X &&
+		r.Min.Y >= bounds.Min.Y && r.Max.Y <= bounds.Max.Y
+}
+
 // DataRange returns the minimum and maximum X and Y values
 func (l *Labels) DataRange() (xmin, xmax, ymin, ymax float64) {
 	return XYRange(l)
@@ -0,0 +1,106 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+type labelledXYs struct {
+	XYs
+	labels []string
+}
+
+func (l labelledXYs) Label(i int) string { return l.labels[i] }
+
+func TestLabelsOverlap(t *testing.T) {
+	// Three points so close that their
+	// labels overlap where they are given.
+	xys := labelledXYs{
+		XYs:    XYs{{0.5, 0.5}, {0.5, 0.5}, {0.5, 0.5}},
+		labels: []string{"first", "second", "third"},
+	}
+	for _, test := range []struct {
+		overlap LabelOverlap
+		leaders int
+	}{
+		{overlap: AllowOverlap, leaders: 0},
+		{overlap: MoveOverlap, leaders: 2},
+		{overlap: DropOverlap, leaders: 2},
+	} {
+		l, err := NewLabels(xys)
+		if err != nil {
+			t.Fatalf("failed to create labels: %v", err)
+		}
+		l.Overlap = test.overlap
+		l.LeaderStyle = draw.LineStyle{Color: color.Black, Width: 1}
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("failed to create plot: %v", err)
+		}
+		p.X.Min, p.X.Max = 0, 1
+		p.Y.Min, p.Y.Max = 0, 1
+
+		r := recorder.New(72)
+		l.Plot(draw.NewCanvas(r, 200, 200), p)
+
+		var boxes []draw.Rectangle
+		leaders := 0
+		for _, a := range r.Actions {
+			switch a := a.(type) {
+			case *recorder.FillString:
+				w, h := l.Width(a.String), l.Height(a.String)
+				min := draw.Point{a.X, a.Y - l.Font.Size + l.Font.Extents().Ascent}
+				boxes = append(boxes, draw.Rectangle{Min: min, Max: draw.Point{min.X + w, min.Y + h}})
+			case *recorder.Stroke:
+				leaders++
+			}
+		}
+		if len(boxes) != 3 {
+			t.Errorf("unexpected number of labels drawn for overlap %d: got:%d want:3", test.overlap, len(boxes))
+		}
+		if leaders != test.leaders {
+			t.Errorf("unexpected number of leader lines for overlap %d: got:%d want:%d", test.overlap, leaders, test.leaders)
+		}
+		if test.overlap == AllowOverlap {
+			continue
+		}
+		for i := range boxes {
+			if overlapsAny(boxes[i], boxes[:i]) {
+				t.Errorf("label %d overlaps an earlier label for overlap %d: %v", i, test.overlap, boxes)
+			}
+		}
+	}
+
+	// With no room for other positions,
+	// overlapping labels are dropped.
+	l, err := NewLabels(xys)
+	if err != nil {
+		t.Fatalf("failed to create labels: %v", err)
+	}
+	l.Overlap = DropOverlap
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 1
+	p.Y.Min, p.Y.Max = 0, 1
+	r := recorder.New(72)
+	l.Plot(draw.NewCanvas(r, l.Width("second"), l.Height("second")), p)
+	n := 0
+	for _, a := range r.Actions {
+		if _, ok := a.(*recorder.FillString); ok {
+			n++
+		}
+	}
+	if n != 1 {
+		t.Errorf("unexpected number of labels drawn in a small canvas: got:%d want:1", n)
+	}
+}
@@ -6,6 +6,8 @@ package plotter
 
 import (
 	"image/color"
+	"math"
+	"sort"
 
 	"github.com/gonum/plot"
 	"github.com/gonum/plot/vg"
@@ -23,6 +25,49 @@ type Line struct {
 
 	// ShadeColor is the color of the shaded area.
 	ShadeColor *color.Color
+
+	// Baseline, if not nil, is the Y value to which
+	// the area under the line is shaded and hatched,
+	// instead of the bottom of the plot.  The area is
+	// split where the line crosses the baseline.
+	Baseline *float64
+
+	// ShadeBelowColor, if not nil, is the color of the
+	// shaded area where the line is below the Baseline,
+	// and ShadeColor is then only used where the line
+	// is above it.
+	ShadeBelowColor *color.Color
+
+	// Hatch is the pattern drawn over the area
+	// between the line and the bottom of the plot,
+	// the same area that is shaded by ShadeColor.
+	// Hatching may be used with or instead of
+	// shading.  The default is no hatching.
+	Hatch draw.Hatch
+
+	// Downsample specifies whether the points of the
+	// line are reduced before drawing.  When Downsample
+	// is true, each run of consecutive points that falls
+	// within a single column of device pixels is replaced
+	// by at most four of its points: the first, the last,
+	// and those with the minimum and maximum Y values.
+	// This greatly reduces the cost of drawing dense data
+	// while preserving its visual peaks.
+	Downsample bool
+
+	// SortByX specifies that the points are joined in
+	// order of their X values, instead of the order in
+	// which they are given, so that unsorted data does
+	// not zigzag back and forth.  Points with equal X
+	// values are kept in the order given.  The points
+	// themselves are not reordered.
+	SortByX bool
+
+	// Name is the name of the line in the plot's
+	// legend.  If Name is the empty string then
+	// the line is not added to the legend by
+	// plot.AutoLegend.
+	Name string
 }
 
 // NewLine returns a Line that uses the default line style and
@@ -42,34 +87,140 @@ func NewLine(xys XYer) (*Line, error) {
 // interface.
 func (pts *Line) Plot(c draw.Canvas, plt *plot.Plot) {
 	trX, trY := plt.Transforms(&c)
-	ps := make([]draw.Point, len(pts.XYs))
+	data := pts.XYs
+	if pts.SortByX {
+		data = append(XYs(nil), data...)
+		sort.Stable(byX(data))
+	}
+	ps := make([]draw.Point, len(data))
 
-	for i, p := range pts.XYs {
+	for i, p := range data {
 		ps[i].X = trX(p.X)
 		ps[i].Y = trY(p.Y)
 	}
+	if pts.Downsample {
+		ps = downsample(ps, vg.Inch/vg.Length(c.DPI()))
+	}
 
-	if pts.ShadeColor != nil && len(ps) > 0 {
-		c.SetColor(*pts.ShadeColor)
-		minY := trY(plt.Y.Min)
-		var pa vg.Path
-		pa.Move(ps[0].X, minY)
-		for i := range pts.XYs {
-			pa.Line(ps[i].X, ps[i].Y)
+	if (pts.ShadeColor != nil || pts.Hatch.Style != draw.NoHatch) && len(ps) > 0 {
+		base := trY(plt.Y.Min)
+		if pts.Baseline != nil {
+			base = trY(*pts.Baseline)
+		}
+		areas := splitAreas(ps, base)
+		if pts.ShadeColor != nil {
+			for _, area := range areas {
+				clr := *pts.ShadeColor
+				if pts.ShadeBelowColor != nil && below(area, base) {
+					clr = *pts.ShadeBelowColor
+				}
+				c.SetColor(clr)
+				var pa vg.Path
+				pa.Move(area[0].X, area[0].Y)
+				for _, p := range area[1:] {
+					pa.Line(p.X, p.Y)
+				}
+				pa.Close()
+				c.Fill(pa)
+			}
+		}
+		if pts.Hatch.Style != draw.NoHatch {
+			for _, area := range areas {
+				c.FillHatch(pts.Hatch, c.ClipPolygonXY(area))
+			}
 		}
-		pa.Line(ps[len(pts.XYs)-1].X, minY)
-		pa.Close()
-		c.Fill(pa)
 	}
 
 	c.StrokeLines(pts.LineStyle, c.ClipLinesXY(ps)...)
 }
 
+// byX sorts points by their X values.
+type byX XYs
+
+func (b byX) Len() int           { return len(b) }
+func (b byX) Less(i, j int) bool { return b[i].X < b[j].X }
+func (b byX) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+
+// splitAreas returns the polygons of the area between the
+// line through ps and the horizontal baseline at base.  A
+// point is inserted on the baseline wherever the line crosses
+// it, so that each polygon lies entirely on one side of it.
+func splitAreas(ps []draw.Point, base vg.Length) [][]draw.Point {
+	var areas [][]draw.Point
+	area := []draw.Point{{ps[0].X, base}, ps[0]}
+	for i := 1; i < len(ps); i++ {
+		p0, p1 := ps[i-1], ps[i]
+		if (p0.Y < base) != (p1.Y < base) {
+			x := p0.X + (p1.X-p0.X)*(base-p0.Y)/(p1.Y-p0.Y)
+			areas = append(areas, append(area, draw.Point{x, base}))
+			area = []draw.Point{{x, base}}
+		}
+		area = append(area, p1)
+	}
+	return append(areas, append(area, draw.Point{ps[len(ps)-1].X, base}))
+}
+
+// below returns whether the area returned by splitAreas
+// lies below the baseline at base.
+func below(area []draw.Point, base vg.Length) bool {
+	for _, p := range area {
+		if p.Y != base {
+			return p.Y < base
+		}
+	}
+	return false
+}
+
+// downsample returns the points of a line reduced to
+// at most four points for each run of consecutive points
+// that fall within the same column of the given width.
+// The first, last, minimum Y and maximum Y points of
+// each run are kept, in their original order.
+func downsample(ps []draw.Point, width vg.Length) []draw.Point {
+	if width <= 0 || len(ps) <= 4 {
+		return ps
+	}
+	col := func(p draw.Point) int64 {
+		return int64(math.Floor(float64(p.X / width)))
+	}
+	var out []draw.Point
+	for start := 0; start < len(ps); {
+		c := col(ps[start])
+		end, min, max := start+1, start, start
+		for ; end < len(ps) && col(ps[end]) == c; end++ {
+			if ps[end].Y < ps[min].Y {
+				min = end
+			}
+			if ps[end].Y > ps[max].Y {
+				max = end
+			}
+		}
+		if min > max {
+			min, max = max, min
+		}
+		last := -1
+		for _, i := range []int{start, min, max, end - 1} {
+			if i == last {
+				continue
+			}
+			out = append(out, ps[i])
+			last = i
+		}
+		start = end
+	}
+	return out
+}
+
 // DataRange returns the minimum and maximum
 // x and y values, implementing the plot.DataRanger
 // interface.
 func (pts *Line) DataRange() (xmin, xmax, ymin, ymax float64) {
-	return XYRange(pts)
+	xmin, xmax, ymin, ymax = XYRange(pts)
+	if pts.Baseline != nil {
+		ymin = math.Min(ymin, *pts.Baseline)
+		ymax = math.Max(ymax, *pts.Baseline)
+	}
+	return xmin, xmax, ymin, ymax
 }
 
 // Thumbnail the thumbnail for the Line,
@@ -86,12 +237,27 @@ func (pts *Line) Thumbnail(c *draw.Canvas) {
 		c.FillPolygon(*pts.ShadeColor, poly)
 
 		points = append(points, draw.Point{c.Min.X, c.Min.Y})
-	} else {
+	}
+	if pts.Hatch.Style != draw.NoHatch {
+		c.FillHatch(pts.Hatch, []draw.Point{
+			{c.Min.X, c.Min.Y},
+			{c.Min.X, c.Max.Y},
+			{c.Max.X, c.Max.Y},
+			{c.Max.X, c.Min.Y},
+		})
+	}
+	if pts.ShadeColor == nil && pts.Hatch.Style == draw.NoHatch {
 		y := c.Center().Y
 		c.StrokeLine2(pts.LineStyle, c.Min.X, y, c.Max.X, y)
 	}
 }
 
+// LegendName returns the Name of the Line,
+// implementing the plot.LegendNamer interface.
+func (pts *Line) LegendName() string {
+	return pts.Name
+}
+
 // NewLinePoints returns both a Line and a
 // Points for the given point data.
 func NewLinePoints(xys XYer) (*Line, *Scatter, error) {
@@ -0,0 +1,173 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+func TestDownsample(t *testing.T) {
+	tests := []struct {
+		in, want []draw.Point
+	}{
+		{
+			in:   []draw.Point{{0, 0}, {1, 1}},
+			want: []draw.Point{{0, 0}, {1, 1}},
+		},
+		{
+			in: []draw.Point{
+				{0, 0}, {0.1, 5}, {0.2, -5}, {0.3, 1}, {0.4, 2},
+				{1.1, 3}, {1.2, 3},
+				{2.5, 7},
+			},
+			want: []draw.Point{
+				{0, 0}, {0.1, 5}, {0.2, -5}, {0.4, 2},
+				{1.1, 3}, {1.2, 3},
+				{2.5, 7},
+			},
+		},
+	}
+	for _, test := range tests {
+		got := downsample(test.in, 1)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected downsampling of %v:\ngot: %v\nwant:%v", test.in, got, test.want)
+		}
+	}
+}
+
+func benchmarkLine(b *testing.B, ds bool) {
+	xys := make(XYs, 1e6)
+	for i := range xys {
+		xys[i].X = float64(i)
+		xys[i].Y = math.Sin(float64(i) / 100)
+	}
+	l, err := NewLine(xys)
+	if err != nil {
+		b.Fatalf("failed to create line: %v", err)
+	}
+	l.Downsample = ds
+	p, err := plot.New()
+	if err != nil {
+		b.Fatalf("failed to create plot: %v", err)
+	}
+	p.Add(l)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r := recorder.New(96)
+		c := draw.NewCanvas(r, 600*vg.Inch/96, 400*vg.Inch/96)
+		l.Plot(c, p)
+	}
+}
+
+func BenchmarkLine1e6(b *testing.B)           { benchmarkLine(b, false) }
+func BenchmarkLine1e6Downsample(b *testing.B) { benchmarkLine(b, true) }
+
+func TestLineBaseline(t *testing.T) {
+	xys := make(XYs, 101)
+	for i := range xys {
+		xys[i].X = 4 * math.Pi * float64(i) / float64(len(xys)-1)
+		xys[i].Y = math.Sin(xys[i].X) + 0.01
+	}
+	l, err := NewLine(xys)
+	if err != nil {
+		t.Fatalf("failed to create line: %v", err)
+	}
+	var zero float64
+	above, below := color.Color(color.Black), color.Color(color.White)
+	l.Baseline = &zero
+	l.ShadeColor = &above
+	l.ShadeBelowColor = &below
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.Add(l)
+	p.Y.Min, p.Y.Max = -2, 2
+
+	r := recorder.New(72)
+	c := draw.NewCanvas(r, 200, 200)
+	l.Plot(c, p)
+	_, trY := p.Transforms(&c)
+	base := trY(0)
+
+	var clr color.Color
+	n := 0
+	for _, a := range r.Actions {
+		switch a := a.(type) {
+		case *recorder.SetColor:
+			clr = a.Color
+		case *recorder.Fill:
+			n++
+			for _, comp := range a.Path {
+				if comp.Type == vg.CloseComp {
+					continue
+				}
+				if clr == above && comp.Y < base-1e-9 || clr == below && comp.Y > base+1e-9 {
+					t.Errorf("fill %d crosses the baseline: %v at y=%v, base=%v", n, clr, comp.Y, base)
+					break
+				}
+			}
+		}
+	}
+	// The line crosses the baseline just before each
+	// multiple of π from π to 4π.
+	if n != 5 {
+		t.Errorf("unexpected number of filled areas: got:%d want:5", n)
+	}
+
+	low := -5.0
+	l.Baseline = &low
+	if _, _, ymin, _ := l.DataRange(); ymin != low {
+		t.Errorf("unexpected data range minimum: got:%g want:%g", ymin, low)
+	}
+}
+
+func TestLineSortByX(t *testing.T) {
+	xys := XYs{{2, 0}, {0, 1}, {1, 2}, {1, 3}}
+	l, err := NewLine(xys)
+	if err != nil {
+		t.Fatalf("failed to create line: %v", err)
+	}
+	l.SortByX = true
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 2
+	p.Y.Min, p.Y.Max = 0, 3
+
+	r := recorder.New(72)
+	c := draw.NewCanvas(r, 200, 200)
+	l.Plot(c, p)
+	var got XYs
+	for _, a := range r.Actions {
+		if s, ok := a.(*recorder.Stroke); ok {
+			for _, pc := range s.Path {
+				got = append(got, struct{ X, Y float64 }{
+					X: math.Floor(float64(pc.X/100) + 0.5),
+					Y: math.Floor(float64(pc.Y*3/200) + 0.5),
+				})
+			}
+		}
+	}
+	// The points with equal X are kept in order.
+	want := XYs{{0, 1}, {1, 2}, {1, 3}, {2, 0}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected line: got:%v want:%v", got, want)
+	}
+	if !reflect.DeepEqual(l.XYs, XYs{{2, 0}, {0, 1}, {1, 2}, {1, 3}}) {
+		t.Errorf("points reordered: %v", l.XYs)
+	}
+}
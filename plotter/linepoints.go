@@ -0,0 +1,163 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// LinePoints implements the Plotter interface, drawing
+// a line connecting a set of points and a glyph at each
+// of the points.  Unlike the pair of plotters returned by
+// NewLinePoints, a LinePoints is a single plotter with a
+// single legend entry showing both the line and a glyph.
+type LinePoints struct {
+	// XYs is a copy of the points.
+	XYs
+
+	// LineStyle is the style of the line
+	// connecting the points.
+	LineStyle draw.LineStyle
+
+	// GlyphStyle is the style of the glyphs
+	// drawn at each point.
+	GlyphStyle draw.GlyphStyle
+
+	// Smooth specifies that the line is drawn as a
+	// Catmull-Rom spline through the points instead
+	// of straight segments between them.  Glyphs are
+	// still drawn only at the points themselves, not
+	// along the interpolated curve.
+	Smooth bool
+
+	// Name is the name of the plotter in the
+	// plot's legend.  If Name is the empty string
+	// then it is not added to the legend by
+	// plot.AutoLegend.
+	Name string
+}
+
+// NewLinePointsPlotter returns a LinePoints that uses the
+// default line and glyph styles.
+func NewLinePointsPlotter(xys XYer) (*LinePoints, error) {
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	return &LinePoints{
+		XYs:        data,
+		LineStyle:  DefaultLineStyle,
+		GlyphStyle: DefaultGlyphStyle,
+	}, nil
+}
+
+// Plot draws the line and then the glyphs,
+// implementing the plot.Plotter interface.
+func (lp *LinePoints) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	ps := make([]draw.Point, len(lp.XYs))
+	for i, p := range lp.XYs {
+		ps[i].X = trX(p.X)
+		ps[i].Y = trY(p.Y)
+	}
+	line := ps
+	if lp.Smooth {
+		line = catmullRom(ps, splineSteps)
+	}
+	c.StrokeLines(lp.LineStyle, c.ClipLinesXY(line)...)
+	for _, p := range ps {
+		c.DrawGlyph(lp.GlyphStyle, p)
+	}
+}
+
+// splineSteps is the number of straight segments used
+// to draw each span of a spline between two points.
+const splineSteps = 16
+
+// catmullRom returns the points of a uniform Catmull-Rom
+// spline through ps, with n segments between each pair of
+// adjacent points.  The end points are repeated to give
+// the tangents at the ends of the spline.
+func catmullRom(ps []draw.Point, n int) []draw.Point {
+	if len(ps) < 3 || n < 2 {
+		return ps
+	}
+	out := make([]draw.Point, 0, (len(ps)-1)*n+1)
+	for i := 0; i+1 < len(ps); i++ {
+		p0, p1, p2, p3 := ps[i], ps[i], ps[i+1], ps[i+1]
+		if i > 0 {
+			p0 = ps[i-1]
+		}
+		if i+2 < len(ps) {
+			p3 = ps[i+2]
+		}
+		for j := 0; j < n; j++ {
+			t := vg.Length(j) / vg.Length(n)
+			t2, t3 := t*t, t*t*t
+			out = append(out, draw.Point{
+				X: 0.5 * (2*p1.X + (p2.X-p0.X)*t + (2*p0.X-5*p1.X+4*p2.X-p3.X)*t2 + (3*p1.X-p0.X-3*p2.X+p3.X)*t3),
+				Y: 0.5 * (2*p1.Y + (p2.Y-p0.Y)*t + (2*p0.Y-5*p1.Y+4*p2.Y-p3.Y)*t2 + (3*p1.Y-p0.Y-3*p2.Y+p3.Y)*t3),
+			})
+		}
+	}
+	return append(out, ps[len(ps)-1])
+}
+
+// DataRange returns the minimum and maximum
+// x and y values, implementing the plot.DataRanger
+// interface.  If Smooth is set then the range
+// includes that of the spline, which may overshoot
+// the points.
+func (lp *LinePoints) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax, ymin, ymax = XYRange(lp)
+	if !lp.Smooth {
+		return xmin, xmax, ymin, ymax
+	}
+	// The spline is computed in data coordinates,
+	// which gives the drawn curve on linear axes,
+	// since the spline is unchanged by scaling and
+	// translation.
+	ps := make([]draw.Point, len(lp.XYs))
+	for i, p := range lp.XYs {
+		ps[i] = draw.Point{X: vg.Length(p.X), Y: vg.Length(p.Y)}
+	}
+	for _, p := range catmullRom(ps, splineSteps) {
+		xmin = math.Min(xmin, float64(p.X))
+		xmax = math.Max(xmax, float64(p.X))
+		ymin = math.Min(ymin, float64(p.Y))
+		ymax = math.Max(ymax, float64(p.Y))
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// GlyphBoxes returns a slice of plot.GlyphBoxes,
+// implementing the plot.GlyphBoxer interface.
+func (lp *LinePoints) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	bs := make([]plot.GlyphBox, len(lp.XYs))
+	for i, p := range lp.XYs {
+		bs[i].X = plt.X.Norm(p.X)
+		bs[i].Y = plt.Y.Norm(p.Y)
+		bs[i].Rectangle = lp.GlyphStyle.Rectangle()
+	}
+	return bs
+}
+
+// Thumbnail draws a line with a glyph at its center,
+// implementing the plot.Thumbnailer interface.
+func (lp *LinePoints) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	c.StrokeLine2(lp.LineStyle, c.Min.X, y, c.Max.X, y)
+	c.DrawGlyph(lp.GlyphStyle, c.Center())
+}
+
+// LegendName returns the Name of the LinePoints,
+// implementing the plot.LegendNamer interface.
+func (lp *LinePoints) LegendName() string {
+	return lp.Name
+}
@@ -0,0 +1,98 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+// countActions returns the number of line strokes
+// with n points and the number of circle glyphs that
+// were recorded.
+func countActions(r *recorder.Canvas, n int) (lines, glyphs int) {
+	for _, a := range r.Actions {
+		switch a := a.(type) {
+		case *recorder.Stroke:
+			if len(a.Path) == n {
+				lines++
+			}
+		case *recorder.Fill:
+			if len(a.Path) == 3 {
+				glyphs++
+			}
+		}
+	}
+	return lines, glyphs
+}
+
+func TestLinePoints(t *testing.T) {
+	xys := XYs{{0, 0}, {1, 2}, {2, 1}, {3, 3}}
+	lp, err := NewLinePointsPlotter(xys)
+	if err != nil {
+		t.Fatalf("failed to create line points: %v", err)
+	}
+	lp.GlyphStyle.Shape = draw.CircleGlyph{}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.HideAxes()
+	p.Add(lp)
+	r := recorder.New(72)
+	p.Draw(draw.NewCanvas(r, 100, 100))
+	if lines, glyphs := countActions(r, len(xys)); lines != 1 || glyphs != len(xys) {
+		t.Errorf("unexpected plot actions: got %d lines and %d glyphs, want 1 and %d", lines, glyphs, len(xys))
+	}
+
+	r.Reset()
+	c := draw.NewCanvas(r, 20, 10)
+	lp.Thumbnail(&c)
+	if lines, glyphs := countActions(r, 2); lines != 1 || glyphs != 1 {
+		t.Errorf("unexpected thumbnail actions: got %d lines and %d glyphs, want 1 and 1", lines, glyphs)
+	}
+}
+
+func TestLinePointsSmooth(t *testing.T) {
+	xys := XYs{{0, 0}, {1, 2}, {2, 1}, {3, 3}, {4, 0}}
+	lp, err := NewLinePointsPlotter(xys)
+	if err != nil {
+		t.Fatalf("failed to create line points: %v", err)
+	}
+	lp.GlyphStyle.Shape = draw.CircleGlyph{}
+	lp.Smooth = true
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.HideAxes()
+	p.Add(lp)
+	// The spline overshoots the points, so the
+	// range of the axes includes it to keep it
+	// from being clipped.
+	if _, _, _, ymax := lp.DataRange(); ymax <= 3 {
+		t.Errorf("range does not include the spline: got Y max %g", ymax)
+	}
+	r := recorder.New(72)
+	p.Draw(draw.NewCanvas(r, 100, 100))
+	n := (len(xys)-1)*splineSteps + 1
+	if lines, glyphs := countActions(r, n); lines != 1 || glyphs != len(xys) {
+		t.Errorf("unexpected plot actions: got %d lines of %d points and %d glyphs, want 1 and %d", lines, n, glyphs, len(xys))
+	}
+
+	// The spline passes through the points.
+	ps := []draw.Point{{0, 0}, {1, 2}, {2, 1}, {3, 3}}
+	spline := catmullRom(ps, 4)
+	for i, p := range ps {
+		if spline[4*i] != p {
+			t.Errorf("spline does not pass through point %d: got:%v want:%v", i, spline[4*i], p)
+		}
+	}
+}
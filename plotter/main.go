@@ -46,6 +46,7 @@ var examples = []struct {
 	{"example_barChart", Example_barChart()},
 	{"example_stackedBarChart", Example_stackedBarChart()},
 	{"example_heatMap", Example_heatMap()},
+	{"example_logGrid", Example_logGrid()},
 }
 
 var formats = []string{
@@ -885,6 +886,33 @@ func Example_heatMap() *plot.Plot {
 	return p
 }
 
+// Example_logGrid draws major and minor grid
+// lines on a plot with a logarithmic Y axis.
+func Example_logGrid() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Minor grid lines"
+	p.Y.Scale = plot.LogScale{}
+	p.Y.Tick.Marker = plot.LogTicks{}
+
+	g := plotter.NewGrid()
+	g.Minor = true
+	p.Add(g)
+
+	exp := plotter.NewFunction(math.Exp)
+	exp.Samples = 100
+	p.Add(exp)
+
+	p.X.Min = 0
+	p.X.Max = 10
+	p.Y.Min = 1
+	p.Y.Max = math.Exp(10)
+
+	return p
+}
+
 func must(p plot.Plotter, err error) plot.Plotter {
 	if err != nil {
 		panic(err)
@@ -0,0 +1,93 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Mesh implements the Plotter interface, drawing a heat
+// map whose cells are bounded by given X and Y edges, so
+// that each column and row of cells may have a different
+// width and height, such as the cells of a spectrogram
+// with logarithmically spaced frequencies.  The edges are
+// mapped through the axes, so the cells follow the scales
+// of the plot.
+//
+// The values of the cells are given by the Z method of
+// the GridXYZ of the embedded HeatMap, which also gives
+// the colors of the cells.  The X and Y methods of the
+// GridXYZ are not used.
+type Mesh struct {
+	HeatMap
+
+	// XEdges are the X coordinates of the edges of
+	// the columns of cells, and YEdges are the Y
+	// coordinates of the edges of the rows.  The
+	// edges are in increasing order, and there is
+	// one more edge than columns or rows.
+	XEdges, YEdges []float64
+}
+
+// NewMesh returns a Mesh of the values of g, with cells
+// bounded by the given edges and colored with the given
+// palette as for NewHeatMap.  An error is returned if the
+// numbers of edges do not match the dimensions of g, or if
+// the edges are not increasing.
+func NewMesh(g GridXYZ, xEdges, yEdges []float64, p palette.Palette) (*Mesh, error) {
+	c, r := g.Dims()
+	if len(xEdges) != c+1 || len(yEdges) != r+1 {
+		return nil, errors.New("Mesh edges do not match the grid dimensions")
+	}
+	for _, edges := range [][]float64{xEdges, yEdges} {
+		for i := 1; i < len(edges); i++ {
+			if !(edges[i] > edges[i-1]) {
+				return nil, errors.New("Mesh edges are not increasing")
+			}
+		}
+	}
+	return &Mesh{
+		HeatMap: *NewHeatMap(g, p),
+		XEdges:  append([]float64(nil), xEdges...),
+		YEdges:  append([]float64(nil), yEdges...),
+	}, nil
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (m *Mesh) Plot(c draw.Canvas, plt *plot.Plot) {
+	fill := m.colors()
+	trX, trY := plt.Transforms(&c)
+	cols, rows := m.GridXYZ.Dims()
+	for i := 0; i < cols; i++ {
+		x0, x1 := trX(m.XEdges[i]), trX(m.XEdges[i+1])
+		for j := 0; j < rows; j++ {
+			col := fill(m.GridXYZ.Z(i, j))
+			if col == nil {
+				continue
+			}
+			y0, y1 := trY(m.YEdges[j]), trY(m.YEdges[j+1])
+			cell := []draw.Point{{x0, y0}, {x1, y0}, {x1, y1}, {x0, y1}}
+			c.FillPolygon(col, c.ClipPolygonXY(cell))
+		}
+	}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (m *Mesh) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return m.XEdges[0], m.XEdges[len(m.XEdges)-1], m.YEdges[0], m.YEdges[len(m.YEdges)-1]
+}
+
+// GlyphBoxes implements the GlyphBoxes method
+// of the plot.GlyphBoxer interface.  The cells
+// of a Mesh extend exactly to its data range,
+// so it has no glyphs.
+func (m *Mesh) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	return nil
+}
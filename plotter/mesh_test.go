@@ -0,0 +1,93 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+func TestMesh(t *testing.T) {
+	g := unitGrid{mat64.NewDense(2, 2, []float64{1, 2, 3, 4})}
+	m, err := NewMesh(g, []float64{0, 1, 3}, []float64{1, 10, 100}, palette.Heat(4, 1))
+	if err != nil {
+		t.Fatalf("failed to create mesh: %v", err)
+	}
+	if xmin, xmax, ymin, ymax := m.DataRange(); xmin != 0 || xmax != 3 || ymin != 1 || ymax != 100 {
+		t.Errorf("unexpected data range: got:%g,%g,%g,%g want:0,3,1,100", xmin, xmax, ymin, ymax)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 3
+	p.Y.Min, p.Y.Max = 1, 100
+	p.Y.Scale = plot.LogScale{}
+
+	r := recorder.New(72)
+	m.Plot(draw.NewCanvas(r, 300, 200), p)
+	var got []draw.Rectangle
+	for _, a := range r.Actions {
+		f, ok := a.(*recorder.Fill)
+		if !ok {
+			continue
+		}
+		b := draw.Rectangle{
+			Min: draw.Point{X: vg.Length(math.Inf(1)), Y: vg.Length(math.Inf(1))},
+			Max: draw.Point{X: vg.Length(math.Inf(-1)), Y: vg.Length(math.Inf(-1))},
+		}
+		for _, pc := range f.Path {
+			if pc.Type == vg.CloseComp {
+				continue
+			}
+			b.Min.X = vg.Length(math.Min(float64(b.Min.X), float64(pc.X)))
+			b.Min.Y = vg.Length(math.Min(float64(b.Min.Y), float64(pc.Y)))
+			b.Max.X = vg.Length(math.Max(float64(b.Max.X), float64(pc.X)))
+			b.Max.Y = vg.Length(math.Max(float64(b.Max.Y), float64(pc.Y)))
+		}
+		got = append(got, b)
+	}
+	// The columns are 100 and 200 wide, and the rows
+	// are each a decade of the logarithmic Y axis.
+	want := []draw.Rectangle{
+		{Min: draw.Point{X: 0, Y: 0}, Max: draw.Point{X: 100, Y: 100}},
+		{Min: draw.Point{X: 0, Y: 100}, Max: draw.Point{X: 100, Y: 200}},
+		{Min: draw.Point{X: 100, Y: 0}, Max: draw.Point{X: 300, Y: 100}},
+		{Min: draw.Point{X: 100, Y: 100}, Max: draw.Point{X: 300, Y: 200}},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected number of cells: got:%d want:%d", len(got), len(want))
+	}
+	for i := range got {
+		if !closeRect(got[i], want[i]) {
+			t.Errorf("unexpected cell %d: got:%v want:%v", i, got[i], want[i])
+		}
+	}
+
+	for _, test := range []struct {
+		x, y []float64
+	}{
+		{x: []float64{0, 1}, y: []float64{1, 10, 100}},
+		{x: []float64{0, 3, 1}, y: []float64{1, 10, 100}},
+	} {
+		if _, err := NewMesh(g, test.x, test.y, palette.Heat(4, 1)); err == nil {
+			t.Errorf("expected error for edges %v and %v", test.x, test.y)
+		}
+	}
+}
+
+func closeRect(a, b draw.Rectangle) bool {
+	const tol = 1e-9
+	return math.Abs(float64(a.Min.X-b.Min.X)) < tol && math.Abs(float64(a.Min.Y-b.Min.Y)) < tol &&
+		math.Abs(float64(a.Max.X-b.Max.X)) < tol && math.Abs(float64(a.Max.Y-b.Max.Y)) < tol
+}
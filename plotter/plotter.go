@@ -154,6 +154,18 @@ func (xys XYs) XY(i int) (float64, float64) {
 	return xys[i].X, xys[i].Y
 }
 
+// DataValues returns the X and Y values of the points,
+// implementing the plot.DataValuer interface for the
+// plotters that embed an XYs.
+func (xys XYs) DataValues() (xs, ys []float64) {
+	xs = make([]float64, len(xys))
+	ys = make([]float64, len(xys))
+	for i, p := range xys {
+		xs[i], ys[i] = p.X, p.Y
+	}
+	return xs, ys
+}
+
 // XValues implements the Valuer interface,
 // returning the x value from an XYer.
 type XValues struct {
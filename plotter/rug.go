@@ -0,0 +1,92 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Rug implements the Plotter interface, drawing a short
+// tick mark at the edge of the data area for each of a
+// set of values.  A Rug shows the distribution of the X
+// or Y values of the points of a Scatter or Line beneath
+// them.
+type Rug struct {
+	Values
+
+	// Vertical specifies that the values are Y values,
+	// marked along the left edge of the data area.  If
+	// Vertical is false then the values are X values,
+	// marked along the bottom edge.
+	Vertical bool
+
+	// Far specifies that the marks are drawn along the
+	// top or the right edge of the data area instead of
+	// the bottom or the left edge.
+	Far bool
+
+	// Length is the length of the marks.
+	Length vg.Length
+
+	// LineStyle is the style of the marks.
+	draw.LineStyle
+}
+
+// NewRug returns a Rug marking the given values along
+// the bottom edge of the data area.
+func NewRug(vs Valuer) (*Rug, error) {
+	values, err := CopyValues(vs)
+	if err != nil {
+		return nil, err
+	}
+	return &Rug{
+		Values:    values,
+		Length:    vg.Points(5),
+		LineStyle: DefaultLineStyle,
+	}, nil
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (r *Rug) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	for _, v := range r.Values {
+		if r.Vertical {
+			y := trY(v)
+			if !c.ContainsY(y) {
+				continue
+			}
+			x0, x1 := c.Min.X, c.Min.X+r.Length
+			if r.Far {
+				x0, x1 = c.Max.X, c.Max.X-r.Length
+			}
+			c.StrokeLine2(r.LineStyle, x0, y, x1, y)
+			continue
+		}
+		x := trX(v)
+		if !c.ContainsX(x) {
+			continue
+		}
+		y0, y1 := c.Min.Y, c.Min.Y+r.Length
+		if r.Far {
+			y0, y1 = c.Max.Y, c.Max.Y-r.Length
+		}
+		c.StrokeLine2(r.LineStyle, x, y0, x, y1)
+	}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.  The range
+// of the axis that the values are not on is empty.
+func (r *Rug) DataRange() (xmin, xmax, ymin, ymax float64) {
+	min, max := Range(r.Values)
+	if r.Vertical {
+		return math.Inf(1), math.Inf(-1), min, max
+	}
+	return min, max, math.Inf(1), math.Inf(-1)
+}
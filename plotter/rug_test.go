@@ -0,0 +1,68 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+func TestRug(t *testing.T) {
+	r, err := NewRug(Values{2, 5, 8, 20})
+	if err != nil {
+		t.Fatalf("failed to create rug: %v", err)
+	}
+	r.Length = 4
+	if xmin, xmax, ymin, ymax := r.DataRange(); xmin != 2 || xmax != 20 || !math.IsInf(ymin, 1) || !math.IsInf(ymax, -1) {
+		t.Errorf("unexpected data range: got:%g,%g,%g,%g want:2,20,+Inf,-Inf", xmin, xmax, ymin, ymax)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 10
+	p.Y.Min, p.Y.Max = 0, 10
+
+	for _, test := range []struct {
+		vertical, far bool
+		want          [][2]draw.Point
+	}{
+		{want: [][2]draw.Point{{{20, 0}, {20, 4}}, {{50, 0}, {50, 4}}, {{80, 0}, {80, 4}}}},
+		{far: true, want: [][2]draw.Point{{{20, 100}, {20, 96}}, {{50, 100}, {50, 96}}, {{80, 100}, {80, 96}}}},
+		{vertical: true, want: [][2]draw.Point{{{0, 20}, {4, 20}}, {{0, 50}, {4, 50}}, {{0, 80}, {4, 80}}}},
+		{vertical: true, far: true, want: [][2]draw.Point{{{100, 20}, {96, 20}}, {{100, 50}, {96, 50}}, {{100, 80}, {96, 80}}}},
+	} {
+		r.Vertical, r.Far = test.vertical, test.far
+		rec := recorder.New(72)
+		r.Plot(draw.NewCanvas(rec, 100, 100), p)
+		var got [][2]draw.Point
+		for _, a := range rec.Actions {
+			s, ok := a.(*recorder.Stroke)
+			if !ok || len(s.Path) != 2 {
+				continue
+			}
+			got = append(got, [2]draw.Point{
+				{X: s.Path[0].X, Y: s.Path[0].Y},
+				{X: s.Path[1].X, Y: s.Path[1].Y},
+			})
+		}
+		if len(got) != len(test.want) {
+			t.Errorf("unexpected number of marks for vertical=%t far=%t: got:%d want:%d",
+				test.vertical, test.far, len(got), len(test.want))
+			continue
+		}
+		for i := range got {
+			if got[i] != test.want[i] {
+				t.Errorf("unexpected mark %d for vertical=%t far=%t: got:%v want:%v",
+					i, test.vertical, test.far, got[i], test.want[i])
+			}
+		}
+	}
+}
@@ -5,7 +5,11 @@
 package plotter
 
 import (
+	"math"
+	"math/rand"
+
 	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
 	"github.com/gonum/plot/vg/draw"
 )
 
@@ -18,6 +22,43 @@ type Scatter struct {
 	// GlyphStyle is the style of the glyphs drawn
 	// at each point.
 	draw.GlyphStyle
+
+	// ClampOutliers specifies whether points outside
+	// of the range of the axes are drawn clamped to the
+	// nearest edge of the data area rather than being
+	// dropped.  Clamped points are drawn using the
+	// ClampGlyphStyle so that they can be distinguished
+	// from the points that are in range.
+	//
+	// Note that the points are still included in the
+	// DataRange, so the axis ranges must be set after
+	// the Scatter is added to a plot in order for any
+	// points to be clamped.
+	ClampOutliers bool
+
+	// ClampGlyphStyle is the style of the glyphs drawn
+	// for clamped points.
+	ClampGlyphStyle draw.GlyphStyle
+
+	// Jitter is the largest horizontal offset that is
+	// added to each glyph, after the point is transformed
+	// to drawing coordinates, to reduce overplotting of
+	// points that share an X value, as in a strip plot
+	// of categorical data.  The offsets are uniformly
+	// distributed in [-Jitter, Jitter).
+	Jitter vg.Length
+
+	// Seed is the seed of the random offsets
+	// used to jitter the points.  The same Seed
+	// gives the same offsets each time the Scatter
+	// is drawn.
+	Seed int64
+
+	// Name is the name of the scatter in the plot's
+	// legend.  If Name is the empty string then
+	// the scatter is not added to the legend by
+	// plot.AutoLegend.
+	Name string
 }
 
 // NewScatter returns a Scatter that uses the
@@ -27,9 +68,12 @@ func NewScatter(xys XYer) (*Scatter, error) {
 	if err != nil {
 		return nil, err
 	}
+	clamp := DefaultGlyphStyle
+	clamp.Shape = draw.CrossGlyph{}
 	return &Scatter{
-		XYs:        data,
-		GlyphStyle: DefaultGlyphStyle,
+		XYs:             data,
+		GlyphStyle:      DefaultGlyphStyle,
+		ClampGlyphStyle: clamp,
 	}, err
 }
 
@@ -37,11 +81,36 @@ func NewScatter(xys XYer) (*Scatter, error) {
 // interface.
 func (pts *Scatter) Plot(c draw.Canvas, plt *plot.Plot) {
 	trX, trY := plt.Transforms(&c)
+	var rnd *rand.Rand
+	if pts.Jitter != 0 {
+		rnd = rand.New(rand.NewSource(pts.Seed))
+	}
 	for _, p := range pts.XYs {
-		c.DrawGlyph(pts.GlyphStyle, draw.Point{trX(p.X), trY(p.Y)})
+		pt := draw.Point{trX(p.X), trY(p.Y)}
+		if rnd != nil {
+			pt.X += pts.Jitter * vg.Length(2*rnd.Float64()-1)
+		}
+		if pts.ClampOutliers && !c.Contains(pt) {
+			pt.X = clampLength(pt.X, c.Min.X, c.Max.X)
+			pt.Y = clampLength(pt.Y, c.Min.Y, c.Max.Y)
+			c.DrawGlyph(pts.ClampGlyphStyle, pt)
+			continue
+		}
+		c.DrawGlyph(pts.GlyphStyle, pt)
 	}
 }
 
+// clampLength returns x clamped to the range [min, max].
+func clampLength(x, min, max vg.Length) vg.Length {
+	if x < min {
+		return min
+	}
+	if x > max {
+		return max
+	}
+	return x
+}
+
 // DataRange returns the minimum and maximum
 // x and y values, implementing the plot.DataRanger
 // interface.
@@ -57,6 +126,13 @@ func (pts *Scatter) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 		bs[i].X = plt.X.Norm(p.X)
 		bs[i].Y = plt.Y.Norm(p.Y)
 		bs[i].Rectangle = pts.GlyphStyle.Rectangle()
+		bs[i].Rectangle.Min.X -= pts.Jitter
+		bs[i].Rectangle.Max.X += pts.Jitter
+		if pts.ClampOutliers && (bs[i].X < 0 || bs[i].X > 1 || bs[i].Y < 0 || bs[i].Y > 1) {
+			bs[i].X = math.Max(0, math.Min(1, bs[i].X))
+			bs[i].Y = math.Max(0, math.Min(1, bs[i].Y))
+			bs[i].Rectangle = pts.ClampGlyphStyle.Rectangle()
+		}
 	}
 	return bs
 }
@@ -66,3 +142,9 @@ func (pts *Scatter) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 func (pts *Scatter) Thumbnail(c *draw.Canvas) {
 	c.DrawGlyph(pts.GlyphStyle, c.Center())
 }
+
+// LegendName returns the Name of the Scatter,
+// implementing the plot.LegendNamer interface.
+func (pts *Scatter) LegendName() string {
+	return pts.Name
+}
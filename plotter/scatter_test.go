@@ -0,0 +1,116 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+// glyphXs returns the X locations of the circle
+// glyphs drawn by the scatter.
+func glyphXs(t *testing.T, s *Scatter) []vg.Length {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.Add(s)
+	p.X.Min, p.X.Max = -1, 1
+	r := recorder.New(72)
+	c := draw.NewCanvas(r, 100, 100)
+	p.Draw(c)
+	var xs []vg.Length
+	for _, a := range r.Actions {
+		if f, ok := a.(*recorder.Fill); ok && len(f.Path) == 3 {
+			xs = append(xs, f.Path[0].X-s.Radius)
+		}
+	}
+	return xs
+}
+
+func TestScatterJitter(t *testing.T) {
+	xys := XYs{{0, 0}, {0, 1}, {0, 2}, {0, 3}}
+	s, err := NewScatter(xys)
+	if err != nil {
+		t.Fatalf("failed to create scatter: %v", err)
+	}
+	s.Shape = draw.CircleGlyph{}
+	plain := glyphXs(t, s)
+	if len(plain) != len(xys) {
+		t.Fatalf("unexpected number of glyphs: got:%d want:%d", len(plain), len(xys))
+	}
+
+	s.Jitter = 5
+	s.Seed = 1
+	first := glyphXs(t, s)
+	if again := glyphXs(t, s); !reflect.DeepEqual(first, again) {
+		t.Errorf("jitter is not reproducible: got:%v then:%v", first, again)
+	}
+	moved := false
+	for i, x := range first {
+		d := x - plain[i]
+		if d < -s.Jitter || d >= s.Jitter {
+			t.Errorf("jitter of point %d out of range: got:%v want in [%v,%v)", i, d, -s.Jitter, s.Jitter)
+		}
+		moved = moved || d != 0
+	}
+	if !moved {
+		t.Error("no points were jittered")
+	}
+
+	s.Seed = 2
+	if other := glyphXs(t, s); reflect.DeepEqual(first, other) {
+		t.Errorf("different seeds gave the same jitter: %v", first)
+	}
+}
+
+func TestScatterClampOutliers(t *testing.T) {
+	s, err := NewScatter(XYs{{5, 5}, {-5, 5}, {5, 20}, {15, -3}, {2, 8}})
+	if err != nil {
+		t.Fatalf("failed to create scatter: %v", err)
+	}
+	s.Shape = draw.CircleGlyph{}
+	s.ClampOutliers = true
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 10
+	p.Y.Min, p.Y.Max = 0, 10
+
+	r := recorder.New(72)
+	s.Plot(draw.NewCanvas(r, 100, 100), p)
+	var in, clamped []draw.Point
+	for _, a := range r.Actions {
+		switch a := a.(type) {
+		case *recorder.Fill:
+			if len(a.Path) == 3 {
+				in = append(in, draw.Point{X: a.Path[0].X - s.Radius, Y: a.Path[0].Y})
+			}
+		case *recorder.Stroke:
+			// Each cross glyph is two strokes
+			// that share their midpoint.
+			if len(a.Path) == 2 {
+				mid := draw.Point{X: (a.Path[0].X + a.Path[1].X) / 2, Y: (a.Path[0].Y + a.Path[1].Y) / 2}
+				if n := len(clamped); n == 0 || clamped[n-1] != mid {
+					clamped = append(clamped, mid)
+				}
+			}
+		}
+	}
+
+	if want := []draw.Point{{50, 50}, {20, 80}}; !reflect.DeepEqual(in, want) {
+		t.Errorf("unexpected in-range points: got:%v want:%v", in, want)
+	}
+	if want := []draw.Point{{0, 50}, {50, 100}, {100, 0}}; !reflect.DeepEqual(clamped, want) {
+		t.Errorf("unexpected clamped points: got:%v want:%v", clamped, want)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// StackedArea implements the Plotter interface, drawing
+// one band of a stacked area chart.  The band is the area
+// between the line through its points and the top of the
+// band below it, or zero for the bottom band.
+type StackedArea struct {
+	// XYs are the points of the top of the band.  Their
+	// Y values are the sums of the values of the series
+	// of the band and of the series of all the bands
+	// below it.
+	XYs
+
+	// Below is the band on which this band is stacked,
+	// which has the same X values.  Below is nil for the
+	// bottom band.
+	Below *StackedArea
+
+	// Color is the fill color of the band.  If Color
+	// is nil then the band is not filled.
+	Color color.Color
+
+	// LineStyle is the style of the line along
+	// the top of the band.
+	draw.LineStyle
+
+	// Name is the name of the band in the plot's
+	// legend, as for Line.
+	Name string
+}
+
+// NewStackedAreas returns the bands of a stacked area chart
+// of the given series, with the first series at the bottom.
+// The series must have the same X values.  The bands are
+// filled with gray and have no lines along their tops, so
+// their colors are typically set to distinguish them.  The
+// bands may be added to a plot in any order, as they do not
+// overlap, and the range of the plot then covers the totals
+// of the series.
+func NewStackedAreas(series ...XYer) ([]*StackedArea, error) {
+	var areas []*StackedArea
+	var below *StackedArea
+	for _, s := range series {
+		data, err := CopyXYs(s)
+		if err != nil {
+			return nil, err
+		}
+		if below != nil {
+			if len(data) != len(below.XYs) {
+				return nil, errors.New("Stacked series have different lengths")
+			}
+			for i := range data {
+				if data[i].X != below.XYs[i].X {
+					return nil, errors.New("Stacked series have different X values")
+				}
+				data[i].Y += below.XYs[i].Y
+			}
+		}
+		a := &StackedArea{
+			XYs:   data,
+			Below: below,
+			Color: color.Gray{Y: 0xd3},
+		}
+		areas = append(areas, a)
+		below = a
+	}
+	return areas, nil
+}
+
+// base returns the Y value of the bottom
+// of the band at its ith point.
+func (a *StackedArea) base(i int) float64 {
+	if a.Below == nil {
+		return 0
+	}
+	return a.Below.XYs[i].Y
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (a *StackedArea) Plot(c draw.Canvas, plt *plot.Plot) {
+	if len(a.XYs) == 0 {
+		return
+	}
+	trX, trY := plt.Transforms(&c)
+	top := make([]draw.Point, len(a.XYs))
+	for i, p := range a.XYs {
+		top[i] = draw.Point{trX(p.X), trY(p.Y)}
+	}
+	if a.Color != nil {
+		// The outline runs along the top of the band
+		// and back along the top of the band below.
+		poly := append([]draw.Point(nil), top...)
+		for i := len(a.XYs) - 1; i >= 0; i-- {
+			poly = append(poly, draw.Point{trX(a.XYs[i].X), trY(a.base(i))})
+		}
+		c.FillPolygon(a.Color, c.ClipPolygonXY(poly))
+	}
+	c.StrokeLines(a.LineStyle, c.ClipLinesXY(top)...)
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (a *StackedArea) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax, ymin, ymax = XYRange(a)
+	for i := range a.XYs {
+		ymin = math.Min(ymin, a.base(i))
+		ymax = math.Max(ymax, a.base(i))
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (a *StackedArea) Thumbnail(c *draw.Canvas) {
+	if a.Color != nil {
+		c.FillPolygon(a.Color, []draw.Point{
+			{c.Min.X, c.Min.Y},
+			{c.Min.X, c.Max.Y},
+			{c.Max.X, c.Max.Y},
+			{c.Max.X, c.Min.Y},
+		})
+		return
+	}
+	y := c.Center().Y
+	c.StrokeLine2(a.LineStyle, c.Min.X, y, c.Max.X, y)
+}
+
+// LegendName returns the Name of the StackedArea,
+// implementing the plot.LegendNamer interface.
+func (a *StackedArea) LegendName() string {
+	return a.Name
+}
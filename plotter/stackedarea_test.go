@@ -0,0 +1,40 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import "testing"
+
+func TestStackedAreas(t *testing.T) {
+	areas, err := NewStackedAreas(
+		XYs{{0, 1}, {1, 2}, {2, 1}},
+		XYs{{0, 2}, {1, 0}, {2, 3}},
+		XYs{{0, 1}, {1, 1}, {2, 1}},
+	)
+	if err != nil {
+		t.Fatalf("failed to create stacked areas: %v", err)
+	}
+	wantTops := [][]float64{{1, 2, 1}, {3, 2, 4}, {4, 3, 5}}
+	wantRanges := [][2]float64{{0, 2}, {1, 4}, {2, 5}}
+	for i, a := range areas {
+		for j, p := range a.XYs {
+			if p.Y != wantTops[i][j] {
+				t.Errorf("unexpected top of band %d at %d: got:%g want:%g", i, j, p.Y, wantTops[i][j])
+			}
+		}
+		// The range runs from the lowest point of the
+		// band below to the highest point of the band.
+		_, _, ymin, ymax := a.DataRange()
+		if ymin != wantRanges[i][0] || ymax != wantRanges[i][1] {
+			t.Errorf("unexpected Y range of band %d: got:%g,%g want:%v", i, ymin, ymax, wantRanges[i])
+		}
+	}
+
+	if _, err := NewStackedAreas(XYs{{0, 1}, {1, 1}}, XYs{{0, 1}, {2, 1}}); err == nil {
+		t.Error("expected an error for different X values")
+	}
+	if _, err := NewStackedAreas(XYs{{0, 1}, {1, 1}}, XYs{{0, 1}}); err == nil {
+		t.Error("expected an error for different lengths")
+	}
+}
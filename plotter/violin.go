@@ -0,0 +1,188 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Violin implements the Plotter interface, drawing
+// a violin plot to represent the distribution of values.
+// The outline of the violin is a kernel density estimate
+// of the values, mirrored about the violin's location,
+// and it spans the range of the values.
+type Violin struct {
+	fiveStatPlot
+
+	// Offset is added to the x location of the violin.
+	// When the Offset is zero, the violin is drawn
+	// centered at its x location.
+	Offset vg.Length
+
+	// Width is the width of the violin at
+	// its widest, where the density is greatest.
+	Width vg.Length
+
+	// Bandwidth is the standard deviation of the
+	// Gaussian kernel used to estimate the density.
+	Bandwidth float64
+
+	// Points is the number of points at which the
+	// density is estimated to draw the outline.
+	Points int
+
+	// Color is the fill color of the violin.  If
+	// Color is nil then the violin is not filled.
+	Color color.Color
+
+	// LineStyle is the style of the outline.
+	draw.LineStyle
+}
+
+// NewViolin returns a new Violin that represents the
+// distribution of the given values.  The bandwidth is
+// chosen by Silverman's rule of thumb,
+//
+//	0.9 * min(σ, IQR/1.34) * n^(-1/5),
+//
+// where σ is the standard deviation of the values and
+// IQR is their interquartile range.
+//
+// An error is returned if the violin is created with
+// no values.
+func NewViolin(w vg.Length, loc float64, values Valuer) (*Violin, error) {
+	if w < 0 {
+		return nil, errors.New("Negative violin width")
+	}
+	if values.Len() == 0 {
+		return nil, ErrNoData
+	}
+
+	v := new(Violin)
+	var err error
+	if v.fiveStatPlot, err = newFiveStat(w, loc, values); err != nil {
+		return nil, err
+	}
+	v.Width = w
+	v.Bandwidth = silverman(v.Values, v.Quartile3-v.Quartile1)
+	v.Points = 100
+	v.Color = color.Gray{Y: 0xd3}
+	v.LineStyle = DefaultLineStyle
+	return v, nil
+}
+
+// silverman returns the bandwidth for a Gaussian kernel
+// density estimate of vs given by Silverman's rule of
+// thumb, where iqr is the interquartile range of vs.
+// If the values are all equal then 1 is returned.
+func silverman(vs Values, iqr float64) float64 {
+	var mean float64
+	for _, v := range vs {
+		mean += v
+	}
+	mean /= float64(len(vs))
+	var ss float64
+	for _, v := range vs {
+		ss += (v - mean) * (v - mean)
+	}
+	sd := 0.0
+	if len(vs) > 1 {
+		sd = math.Sqrt(ss / float64(len(vs)-1))
+	}
+
+	s := sd
+	if iqr > 0 && iqr/1.34 < s {
+		s = iqr / 1.34
+	}
+	if s == 0 {
+		return 1
+	}
+	return 0.9 * s * math.Pow(float64(len(vs)), -0.2)
+}
+
+// Density returns the kernel density estimate of the
+// values at the Points evenly spaced points from Min to
+// Max, using a Gaussian kernel with the Bandwidth.  The
+// X field of each point is the value and the Y field is
+// the density.
+func (v *Violin) Density() XYs {
+	n := v.Points
+	if n < 2 {
+		n = 2
+	}
+	d := make(XYs, n)
+	norm := 1 / (float64(len(v.Values)) * v.Bandwidth * math.Sqrt(2*math.Pi))
+	for i := range d {
+		x := v.Min + (v.Max-v.Min)*float64(i)/float64(n-1)
+		var y float64
+		for _, val := range v.Values {
+			z := (x - val) / v.Bandwidth
+			y += math.Exp(-z * z / 2)
+		}
+		d[i].X, d[i].Y = x, y*norm
+	}
+	return d
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (v *Violin) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	x := trX(v.Location)
+	if !c.ContainsX(x) {
+		return
+	}
+	x += v.Offset
+
+	d := v.Density()
+	max := 0.0
+	for _, p := range d {
+		max = math.Max(max, p.Y)
+	}
+	if max == 0 {
+		return
+	}
+
+	// The right half of the outline is traced up
+	// and the left half traced back down.
+	pts := make([]draw.Point, 2*len(d))
+	for i, p := range d {
+		w := v.Width / 2 * vg.Length(p.Y/max)
+		y := trY(p.X)
+		pts[i] = draw.Point{x + w, y}
+		pts[len(pts)-1-i] = draw.Point{x - w, y}
+	}
+	if v.Color != nil {
+		c.FillPolygon(v.Color, c.ClipPolygonY(pts))
+	}
+	pts = append(pts, pts[0])
+	c.StrokeLines(v.LineStyle, c.ClipLinesY(pts)...)
+}
+
+// DataRange returns the minimum and maximum x
+// and y values, implementing the plot.DataRanger
+// interface.
+func (v *Violin) DataRange() (float64, float64, float64, float64) {
+	return v.Location, v.Location, v.Min, v.Max
+}
+
+// GlyphBoxes returns a slice of GlyphBoxes for the
+// width of the violin, implementing the plot.GlyphBoxer
+// interface.
+func (v *Violin) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	return []plot.GlyphBox{{
+		X: plt.X.Norm(v.Location),
+		Y: plt.Y.Norm(v.Median),
+		Rectangle: draw.Rectangle{
+			Min: draw.Point{X: v.Offset - (v.Width/2 + v.LineStyle.Width/2)},
+			Max: draw.Point{X: v.Offset + (v.Width/2 + v.LineStyle.Width/2)},
+		},
+	}}
+}
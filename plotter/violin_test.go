@@ -0,0 +1,61 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+func TestViolin(t *testing.T) {
+	v, err := NewViolin(20, 1, Values{1, 2, 3, 4, 5})
+	if err != nil {
+		t.Fatalf("failed to create violin: %v", err)
+	}
+	// σ is sqrt(2.5), which is less than IQR/1.34 = 2.5/1.34.
+	want := 0.9 * math.Sqrt(2.5) * math.Pow(5, -0.2)
+	if math.Abs(v.Bandwidth-want) > 1e-12 {
+		t.Errorf("unexpected bandwidth: got:%g want:%g", v.Bandwidth, want)
+	}
+
+	d := v.Density()
+	if len(d) != v.Points {
+		t.Fatalf("unexpected number of density points: got:%d want:%d", len(d), v.Points)
+	}
+	if d[0].X != 1 || d[len(d)-1].X != 5 {
+		t.Errorf("unexpected density range: got:[%g,%g] want:[1,5]", d[0].X, d[len(d)-1].X)
+	}
+	for i := range d {
+		if j := len(d) - 1 - i; math.Abs(d[i].Y-d[j].Y) > 1e-12 {
+			t.Errorf("asymmetric density at %g and %g: %g != %g", d[i].X, d[j].X, d[i].Y, d[j].Y)
+		}
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.Add(v)
+	r := recorder.New(72)
+	p.Draw(draw.NewCanvas(r, 100, 100))
+	var fills int
+	for _, a := range r.Actions {
+		if _, ok := a.(*recorder.Fill); ok {
+			fills++
+		}
+	}
+	// The background and the violin are filled.
+	if fills != 2 {
+		t.Errorf("unexpected number of fills: got:%d want:2", fills)
+	}
+
+	if _, err := NewViolin(20, 0, Values{}); err != ErrNoData {
+		t.Errorf("unexpected error for no values: got:%v want:%v", err, ErrNoData)
+	}
+}
@@ -78,6 +78,53 @@ func AddStackedAreaPlots(plt *plot.Plot, xs plotter.Valuer, vs ...interface{}) e
 	return nil
 }
 
+// AddStackedAreas adds the bands of a stacked area chart
+// to a plot, as made by plotter.NewStackedAreas.  The
+// variadic arguments must be either strings or
+// plotter.XYers with the same X values.  Each plotter.XYer
+// adds a band stacked on the bands added before it, and
+// filled with the next color via the Color function.  If a
+// plotter.XYer is immediately preceeded by a string then a
+// legend entry is added to the plot using the string as
+// the name.  Unlike AddStackedAreaPlots, the values are
+// summed, so each series is given by its own values.
+//
+// If an error occurs then none of the plotters are added
+// to the plot, and the error is returned.
+func AddStackedAreas(plt *plot.Plot, vs ...interface{}) error {
+	var series []plotter.XYer
+	var names []string
+	name := ""
+	for _, v := range vs {
+		switch t := v.(type) {
+		case string:
+			name = t
+
+		case plotter.XYer:
+			series = append(series, t)
+			names = append(names, name)
+			name = ""
+
+		default:
+			panic(fmt.Sprintf("AddStackedAreas handles strings and plotter.XYers, got %T", t))
+		}
+	}
+
+	areas, err := plotter.NewStackedAreas(series...)
+	if err != nil {
+		return err
+	}
+	for i, a := range areas {
+		a.Color = Color(i)
+		a.Name = names[i]
+		plt.Add(a)
+		if a.Name != "" {
+			plt.Legend.Add(a.Name, a)
+		}
+	}
+	return nil
+}
+
 // AddBoxPlots adds box plot plotters to a plot and
 // sets the X axis of the plot to be nominal.
 // The variadic arguments must be either strings
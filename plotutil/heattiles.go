@@ -0,0 +1,150 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"errors"
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/plotter"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// HeatMapTiles is a set of heat map plots that are
+// drawn tiled in a grid.  All of the heat maps share a
+// common color scale, which is shown by a single color
+// bar drawn along the right edge of the tiles.
+type HeatMapTiles struct {
+	// Plots are the plots of each of the heat maps,
+	// in the order that they are tiled: left to right,
+	// then top to bottom.  The plots may be customized,
+	// for example to add titles, before drawing.
+	Plots []*plot.Plot
+
+	// HeatMaps are the heat maps drawn in each
+	// of the Plots.
+	HeatMaps []*plotter.HeatMap
+
+	// ColorBar is the plot of the shared color bar.
+	ColorBar *plot.Plot
+
+	// Cols is the number of columns of tiles.
+	Cols int
+
+	// ColorBarWidth is the width of the color bar
+	// plot, including its axis.
+	ColorBarWidth vg.Length
+
+	// colorBar is the color bar plotter
+	// drawn in the ColorBar plot.
+	colorBar *plotter.ColorBar
+}
+
+// NewHeatMapTiles returns a new HeatMapTiles with a heat map
+// plot for each of the grids, tiled with the given number of
+// columns.  The common color scale spans the minimum to the
+// maximum value across all of the grids, ignoring NaNs.
+func NewHeatMapTiles(cols int, pal palette.Palette, grids ...plotter.GridXYZ) (*HeatMapTiles, error) {
+	if cols <= 0 {
+		return nil, errors.New("Number of columns is not positive")
+	}
+	if len(grids) == 0 {
+		return nil, plotter.ErrNoData
+	}
+
+	min, max := math.Inf(1), math.Inf(-1)
+	t := &HeatMapTiles{
+		Cols:          cols,
+		ColorBarWidth: vg.Inch,
+	}
+	for _, g := range grids {
+		h := plotter.NewHeatMap(g, pal)
+		min = math.Min(min, h.Min)
+		max = math.Max(max, h.Max)
+		t.HeatMaps = append(t.HeatMaps, h)
+
+		p, err := plot.New()
+		if err != nil {
+			return nil, err
+		}
+		p.Add(h)
+		t.Plots = append(t.Plots, p)
+	}
+	if math.IsInf(min, 0) || math.IsInf(max, 0) {
+		return nil, plotter.ErrNoData
+	}
+	for _, h := range t.HeatMaps {
+		h.Min, h.Max = min, max
+	}
+
+	cb, err := plot.New()
+	if err != nil {
+		return nil, err
+	}
+	cb.HideX()
+	t.colorBar = plotter.NewColorBar(pal, min, max)
+	cb.Add(t.colorBar)
+	t.ColorBar = cb
+
+	return t, nil
+}
+
+// SetLog sets whether the heat maps and the color bar
+// map values to colors on a logarithmic scale, and gives
+// the color bar axis log-spaced ticks.  If log is true and
+// the minimum of the common color scale is not positive
+// then it is raised to the least positive value of the grids.
+func (t *HeatMapTiles) SetLog(log bool) {
+	cb := t.colorBar
+	if log && !(cb.Min > 0) {
+		cb.Min = math.Inf(1)
+		for _, h := range t.HeatMaps {
+			c, r := h.GridXYZ.Dims()
+			for i := 0; i < c; i++ {
+				for j := 0; j < r; j++ {
+					if v := h.GridXYZ.Z(i, j); v > 0 && v < cb.Min {
+						cb.Min = v
+					}
+				}
+			}
+		}
+	}
+	for _, h := range t.HeatMaps {
+		h.Min, h.Log = cb.Min, log
+	}
+	cb.Log = log
+	if log {
+		t.ColorBar.Y.Scale = plot.LogScale{}
+		t.ColorBar.Y.Tick.Marker = plot.LogTicks{}
+	} else {
+		t.ColorBar.Y.Scale = plot.LinearScale{}
+		t.ColorBar.Y.Tick.Marker = plot.DefaultTicks{}
+	}
+	t.ColorBar.Y.Min, t.ColorBar.Y.Max = cb.Min, cb.Max
+}
+
+// Draw draws the tiled heat maps and the color bar
+// to the given draw.Canvas.
+func (t *HeatMapTiles) Draw(c draw.Canvas) {
+	t.ColorBar.Draw(c.Crop(c.Size().X-t.ColorBarWidth, 0, 0, 0))
+
+	c = c.Crop(0, 0, -t.ColorBarWidth, 0)
+	rows := (len(t.Plots) + t.Cols - 1) / t.Cols
+	w := c.Size().X / vg.Length(t.Cols)
+	h := c.Size().Y / vg.Length(rows)
+	for i, p := range t.Plots {
+		col, row := vg.Length(i%t.Cols), vg.Length(i/t.Cols)
+		p.Draw(draw.Canvas{
+			Canvas: c.Canvas,
+			Rectangle: draw.Rectangle{
+				Min: draw.Point{c.Min.X + col*w, c.Max.Y - (row+1)*h},
+				Max: draw.Point{c.Min.X + (col+1)*w, c.Max.Y - row*h},
+			},
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/plotter"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+// grid is a 2×2 plotter.GridXYZ.
+type grid [4]float64
+
+func (g grid) Dims() (c, r int)   { return 2, 2 }
+func (g grid) Z(c, r int) float64 { return g[r*2+c] }
+func (g grid) X(c int) float64    { return float64(c) }
+func (g grid) Y(r int) float64    { return float64(r) }
+
+func TestHeatMapTiles(t *testing.T) {
+	tiles, err := NewHeatMapTiles(2, palette.Heat(8, 1),
+		grid{0, 1, 2, 3},
+		grid{-4, 1, 2, 3},
+		grid{0, 1, 2, 10},
+	)
+	if err != nil {
+		t.Fatalf("failed to create tiles: %v", err)
+	}
+	if len(tiles.Plots) != 3 {
+		t.Errorf("unexpected number of plots: got:%d want:3", len(tiles.Plots))
+	}
+	for i, h := range tiles.HeatMaps {
+		if h.Min != -4 || h.Max != 10 {
+			t.Errorf("unexpected range for heat map %d: got:[%g,%g] want:[-4,10]", i, h.Min, h.Max)
+		}
+	}
+	tiles.Draw(draw.NewCanvas(recorder.New(96), 500, 300))
+
+	if _, err := NewHeatMapTiles(2, palette.Heat(8, 1)); err != plotter.ErrNoData {
+		t.Errorf("unexpected error for no grids: got:%v want:%v", err, plotter.ErrNoData)
+	}
+}
+
+func TestHeatMapTilesSetLog(t *testing.T) {
+	tiles, err := NewHeatMapTiles(2, palette.Heat(8, 1),
+		grid{0, 1, 2, 3},
+		grid{-4, 0.5, 2, 100},
+	)
+	if err != nil {
+		t.Fatalf("failed to create tiles: %v", err)
+	}
+	tiles.SetLog(true)
+	for i, h := range tiles.HeatMaps {
+		if !h.Log || h.Min != 0.5 || h.Max != 100 {
+			t.Errorf("unexpected scale for heat map %d: got:[%g,%g] log:%t want:[0.5,100] log:true",
+				i, h.Min, h.Max, h.Log)
+		}
+	}
+	tiles.Draw(draw.NewCanvas(recorder.New(96), 500, 300))
+	if _, ok := tiles.ColorBar.Y.Tick.Marker.(plot.LogTicks); !ok {
+		t.Errorf("unexpected color bar ticks: got:%T want:plot.LogTicks", tiles.ColorBar.Y.Tick.Marker)
+	}
+}
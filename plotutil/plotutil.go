@@ -82,7 +82,11 @@ func Shape(i int) draw.GlyphDrawer {
 }
 
 // DefaultDashes is a set of dash patterns used by
-// the Dashes function.
+// the Dashes function, starting with a solid line, so
+// that the series added by Series, AddLines and
+// AddLinePoints can be told apart when printed in
+// black and white.  Setting DefaultDashes to nil
+// draws all of the series with solid lines.
 var DefaultDashes = [][]vg.Length{
 	{},
 
@@ -110,9 +114,14 @@ var DefaultDashes = [][]vg.Length{
 // Dashes returns the ith default dash pattern,
 // wrapping if i is less than zero or greater
 // than the max number of dash patters
-// in the DefaultDashes slice.
+// in the DefaultDashes slice.  If DefaultDashes
+// is empty then Dashes returns nil, giving a
+// solid line.
 func Dashes(i int) []vg.Length {
 	n := len(DefaultDashes)
+	if n == 0 {
+		return nil
+	}
 	if i < 0 {
 		return DefaultDashes[i%n+n]
 	}
@@ -0,0 +1,116 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/plotter"
+	"github.com/gonum/plot/vg"
+)
+
+// Series adds named data series to a plot one at a time.
+// Each series is drawn using the next color, dashes and
+// glyph shape via the Color, Dashes and Shape functions.
+// The series are not added to the plot's legend; call
+// the plot's AutoLegend method once they are all added
+// to list those with names that are not the empty string.
+type Series struct {
+	// Plot is the plot to which the series are added.
+	Plot *plot.Plot
+
+	// n is the number of series added so far.
+	n int
+}
+
+// NewSeries returns a new Series that adds
+// data series to the given plot.
+func NewSeries(p *plot.Plot) *Series {
+	return &Series{Plot: p}
+}
+
+// AddLine adds a Line plotter of the given points
+// to the plot, returning the new plotter so that it
+// may be customized further.
+func (s *Series) AddLine(name string, xys plotter.XYer) (*plotter.Line, error) {
+	l, err := plotter.NewLine(xys)
+	if err != nil {
+		return nil, err
+	}
+	l.Color = Color(s.n)
+	l.Dashes = Dashes(s.n)
+	l.Name = name
+	s.add(l)
+	return l, nil
+}
+
+// AddScatter adds a Scatter plotter of the given points
+// to the plot, returning the new plotter so that it
+// may be customized further.
+func (s *Series) AddScatter(name string, xys plotter.XYer) (*plotter.Scatter, error) {
+	sc, err := plotter.NewScatter(xys)
+	if err != nil {
+		return nil, err
+	}
+	sc.Color = Color(s.n)
+	sc.Shape = Shape(s.n)
+	sc.Name = name
+	s.add(sc)
+	return sc, nil
+}
+
+// AddBarChart adds a BarChart plotter of the given
+// values to the plot, returning the new plotter so that
+// it may be customized further, for example by setting
+// its Offset to group it with other bar charts.
+func (s *Series) AddBarChart(name string, vs plotter.Valuer, width vg.Length) (*plotter.BarChart, error) {
+	b, err := plotter.NewBarChart(vs, width)
+	if err != nil {
+		return nil, err
+	}
+	b.Color = Color(s.n)
+	b.Name = name
+	s.add(b)
+	return b, nil
+}
+
+// add adds the plotter for the next series to the plot.
+func (s *Series) add(p plot.Plotter) {
+	s.n++
+	s.Plot.Add(p)
+}
+
+// MultiLine returns a new plot with a Line for each of the
+// named series of Y values, all sharing the X values.  The
+// lines are added as by Series, in the order of their names,
+// and each appears in the legend under its name.  An error
+// is returned if a series does not have the same length as
+// the X values.
+func MultiLine(x []float64, ys map[string][]float64) (*plot.Plot, error) {
+	p, err := plot.New()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(ys))
+	for name := range ys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	s := NewSeries(p)
+	for _, name := range names {
+		y := ys[name]
+		if len(y) != len(x) {
+			return nil, fmt.Errorf("plotutil: series %q: X/Y length mismatch: %d != %d", name, len(x), len(y))
+		}
+		if _, err := s.AddLine(name, combineXYs{xs: plotter.Values(x), ys: plotter.Values(y)}); err != nil {
+			return nil, fmt.Errorf("plotutil: series %q: %v", name, err)
+		}
+	}
+	p.AutoLegend()
+	return p, nil
+}
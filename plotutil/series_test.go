@@ -0,0 +1,118 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/plotter"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+func TestSeries(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	s := NewSeries(p)
+	l, err := s.AddLine("line", plotter.XYs{{0, 0}, {1, 10}})
+	if err != nil {
+		t.Fatalf("failed to add line: %v", err)
+	}
+	sc, err := s.AddScatter("", plotter.XYs{{-1, 2}, {3, 4}})
+	if err != nil {
+		t.Fatalf("failed to add scatter: %v", err)
+	}
+	b, err := s.AddBarChart("bars", plotter.Values{1, 20}, 1)
+	if err != nil {
+		t.Fatalf("failed to add bar chart: %v", err)
+	}
+
+	if l.Color != Color(0) || sc.Color != Color(1) || b.Color != Color(2) {
+		t.Error("series do not use successive colors")
+	}
+	if l.Name != "line" || b.Name != "bars" {
+		t.Errorf("unexpected series names: got:%q,%q want:\"line\",\"bars\"", l.Name, b.Name)
+	}
+	if p.X.Min != -1 || p.X.Max != 3 || p.Y.Min != 0 || p.Y.Max != 20 {
+		t.Errorf("unexpected plot range: got:X=[%g,%g] Y=[%g,%g] want:X=[-1,3] Y=[0,20]",
+			p.X.Min, p.X.Max, p.Y.Min, p.Y.Max)
+	}
+
+	// The named series are listed once by AutoLegend.
+	p.AutoLegend()
+	if got, want := legendNames(p), []string{"line", "bars"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected legend entries: got:%q want:%q", got, want)
+	}
+}
+
+// legendNames returns the names drawn in the legend of p.
+func legendNames(p *plot.Plot) []string {
+	r := recorder.New(72)
+	p.Legend.Draw(draw.NewCanvas(r, 100, 100))
+	var names []string
+	for _, a := range r.Actions {
+		if fs, ok := a.(*recorder.FillString); ok {
+			names = append(names, fs.String)
+		}
+	}
+	return names
+}
+
+func TestMultiLine(t *testing.T) {
+	p, err := MultiLine([]float64{0, 1, 2}, map[string][]float64{
+		"b": {1, 5, 2},
+		"a": {-3, 0, 0},
+	})
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	if p.X.Min != 0 || p.X.Max != 2 || p.Y.Min != -3 || p.Y.Max != 5 {
+		t.Errorf("unexpected plot range: got:X=[%g,%g] Y=[%g,%g] want:X=[0,2] Y=[-3,5]",
+			p.X.Min, p.X.Max, p.Y.Min, p.Y.Max)
+	}
+	if got, want := legendNames(p), []string{"a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected legend entries: got:%q want:%q", got, want)
+	}
+
+	_, err = MultiLine([]float64{0, 1}, map[string][]float64{"short": {1}})
+	if err == nil {
+		t.Error("expected an error for mismatched lengths")
+	}
+}
+
+func TestSeriesDashes(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	s := NewSeries(p)
+	var lines []*plotter.Line
+	for i := 0; i < 3; i++ {
+		l, err := s.AddLine("", plotter.XYs{{0, 0}, {1, 1}})
+		if err != nil {
+			t.Fatalf("failed to add line: %v", err)
+		}
+		lines = append(lines, l)
+	}
+	if len(lines[0].Dashes) != 0 || len(lines[1].Dashes) == 0 ||
+		reflect.DeepEqual(lines[1].Dashes, lines[2].Dashes) {
+		t.Errorf("lines do not use successive dashes: %v", [][]vg.Length{lines[0].Dashes, lines[1].Dashes, lines[2].Dashes})
+	}
+
+	defer func(d [][]vg.Length) { DefaultDashes = d }(DefaultDashes)
+	DefaultDashes = nil
+	l, err := s.AddLine("", plotter.XYs{{0, 0}, {1, 1}})
+	if err != nil {
+		t.Fatalf("failed to add line: %v", err)
+	}
+	if l.Dashes != nil {
+		t.Errorf("unexpected dashes with no default dashes: %v", l.Dashes)
+	}
+}
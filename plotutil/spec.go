@@ -0,0 +1,219 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/plotter"
+	"github.com/gonum/plot/vg"
+)
+
+// Spec is a declarative description of a plot that can
+// be built by FromSpec.  A Spec is typically decoded from
+// JSON, allowing plots to be described by programs that
+// are not written in Go.  Fields that are left at their
+// zero values are given the defaults of plot.New and of
+// the plotters' constructors.
+type Spec struct {
+	// Title is the title of the plot.
+	Title string `json:"title,omitempty"`
+
+	// X and Y describe the axes of the plot.
+	X AxisSpec `json:"x"`
+	Y AxisSpec `json:"y"`
+
+	// Legend describes the placement of the legend.
+	Legend LegendSpec `json:"legend"`
+
+	// Series are the data series of the plot, drawn
+	// in order.  Series without an explicit color are
+	// styled in turn in the same way as by Series.
+	Series []SeriesSpec `json:"series"`
+}
+
+// AxisSpec is the description of an axis of a Spec.
+type AxisSpec struct {
+	// Label is the text of the axis label.
+	Label string `json:"label,omitempty"`
+
+	// Min and Max override the range of the axis
+	// computed from the data if they are not nil.
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+
+	// Log specifies a logarithmic scale and ticks.
+	Log bool `json:"log,omitempty"`
+
+	// Hide specifies that the axis is not drawn.
+	Hide bool `json:"hide,omitempty"`
+}
+
+// LegendSpec is the description of the legend of a Spec.
+type LegendSpec struct {
+	// Top and Left give the corner of the plot in which
+	// the legend is drawn, as for plot.Legend.
+	Top  bool `json:"top,omitempty"`
+	Left bool `json:"left,omitempty"`
+}
+
+// SeriesSpec is the description of a data series of a Spec.
+type SeriesSpec struct {
+	// Type is the kind of plotter used to draw the
+	// series: "line", "scatter" or "bars".
+	Type string `json:"type"`
+
+	// Name is the legend entry of the series.  If
+	// Name is empty then the series is not added
+	// to the legend.
+	Name string `json:"name,omitempty"`
+
+	// X and Y are the coordinates of the points of
+	// a line or scatter series, and they must have
+	// the same length.  Y gives the heights of the
+	// bars of a bars series, and X is ignored.
+	X []float64 `json:"x,omitempty"`
+	Y []float64 `json:"y"`
+
+	// Color is the color of the series as a
+	// hexadecimal "#rrggbb" or "#rrggbbaa" string.
+	Color string `json:"color,omitempty"`
+
+	// Width is the width in points of the line of a
+	// line series, the radius of the glyphs of a
+	// scatter series or the width of the bars of a
+	// bars series.
+	Width float64 `json:"width,omitempty"`
+}
+
+// FromSpec returns a new plot built from the given Spec.
+// An error is returned if the Spec has an unknown series
+// type, a badly formed color, or a series whose data can
+// not be plotted.
+func FromSpec(spec Spec) (*plot.Plot, error) {
+	p, err := plot.New()
+	if err != nil {
+		return nil, err
+	}
+	p.Title.Text = spec.Title
+	p.Legend.Top = spec.Legend.Top
+	p.Legend.Left = spec.Legend.Left
+
+	s := NewSeries(p)
+	for i, ss := range spec.Series {
+		if err := s.addSpec(ss); err != nil {
+			return nil, fmt.Errorf("plotutil: series %d: %v", i, err)
+		}
+	}
+	p.AutoLegend()
+
+	spec.X.apply(&p.X)
+	spec.Y.apply(&p.Y)
+	if spec.X.Hide {
+		p.HideX()
+	}
+	if spec.Y.Hide {
+		p.HideY()
+	}
+	return p, nil
+}
+
+// apply sets the fields of the axis described by the AxisSpec.
+func (a AxisSpec) apply(axis *plot.Axis) {
+	axis.Label.Text = a.Label
+	if a.Min != nil {
+		axis.Min = *a.Min
+	}
+	if a.Max != nil {
+		axis.Max = *a.Max
+	}
+	if a.Log {
+		axis.Scale = plot.LogScale{}
+		axis.Tick.Marker = plot.LogTicks{}
+	}
+}
+
+// addSpec adds the series described by the SeriesSpec.
+func (s *Series) addSpec(ss SeriesSpec) error {
+	var c color.Color
+	if ss.Color != "" {
+		var err error
+		if c, err = parseColor(ss.Color); err != nil {
+			return err
+		}
+	}
+
+	switch ss.Type {
+	case "line", "scatter":
+		if len(ss.X) != len(ss.Y) {
+			return fmt.Errorf("X/Y length mismatch: %d != %d", len(ss.X), len(ss.Y))
+		}
+		xys := make(plotter.XYs, len(ss.X))
+		for i := range xys {
+			xys[i].X, xys[i].Y = ss.X[i], ss.Y[i]
+		}
+		if ss.Type == "line" {
+			l, err := s.AddLine(ss.Name, xys)
+			if err != nil {
+				return err
+			}
+			if c != nil {
+				l.Color = c
+			}
+			if ss.Width > 0 {
+				l.Width = vg.Points(ss.Width)
+			}
+			return nil
+		}
+		sc, err := s.AddScatter(ss.Name, xys)
+		if err != nil {
+			return err
+		}
+		if c != nil {
+			sc.Color = c
+		}
+		if ss.Width > 0 {
+			sc.Radius = vg.Points(ss.Width)
+		}
+
+	case "bars":
+		w := vg.Points(ss.Width)
+		if w <= 0 {
+			w = vg.Points(20)
+		}
+		b, err := s.AddBarChart(ss.Name, plotter.Values(ss.Y), w)
+		if err != nil {
+			return err
+		}
+		if c != nil {
+			b.Color = c
+		}
+
+	default:
+		return fmt.Errorf("unknown series type %q", ss.Type)
+	}
+	return nil
+}
+
+// parseColor returns the color given by a hexadecimal
+// "#rrggbb" or "#rrggbbaa" string.
+func parseColor(s string) (color.Color, error) {
+	h := strings.TrimPrefix(s, "#")
+	if len(h) == 6 {
+		h += "ff"
+	}
+	if len(h) != 8 || !strings.HasPrefix(s, "#") {
+		return nil, fmt.Errorf("bad color %q", s)
+	}
+	v, err := strconv.ParseUint(h, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("bad color %q", s)
+	}
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
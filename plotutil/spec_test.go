@@ -0,0 +1,79 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"encoding/json"
+	"image/color"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+func TestFromSpec(t *testing.T) {
+	max := 100.0
+	spec := Spec{
+		Title:  "spec",
+		X:      AxisSpec{Label: "x"},
+		Y:      AxisSpec{Label: "y", Max: &max, Log: true},
+		Legend: LegendSpec{Top: true},
+		Series: []SeriesSpec{
+			{Type: "line", Name: "line", X: []float64{0, 1, 2}, Y: []float64{1, 10, 20}, Color: "#ff000080", Width: 2},
+			{Type: "scatter", X: []float64{0.5, 1.5}, Y: []float64{5, 15}},
+		},
+	}
+
+	b, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("failed to marshal spec: %v", err)
+	}
+	var got Spec
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("failed to unmarshal spec: %v", err)
+	}
+	if !reflect.DeepEqual(got, spec) {
+		t.Fatalf("spec does not round-trip:\ngot: %+v\nwant:%+v", got, spec)
+	}
+
+	p, err := FromSpec(got)
+	if err != nil {
+		t.Fatalf("failed to build plot: %v", err)
+	}
+	if p.Title.Text != "spec" || p.X.Label.Text != "x" || p.Y.Label.Text != "y" || !p.Legend.Top {
+		t.Error("plot fields not set from spec")
+	}
+	if p.X.Min != 0 || p.X.Max != 2 || p.Y.Min != 1 || p.Y.Max != 100 {
+		t.Errorf("unexpected plot range: got:X=[%g,%g] Y=[%g,%g] want:X=[0,2] Y=[1,100]",
+			p.X.Min, p.X.Max, p.Y.Min, p.Y.Max)
+	}
+	if _, ok := p.Y.Scale.(plot.LogScale); !ok {
+		t.Errorf("unexpected Y scale: got:%T want:plot.LogScale", p.Y.Scale)
+	}
+	r := recorder.New(72)
+	p.Draw(draw.NewCanvas(r, 200, 200))
+	var line, scatter bool
+	for _, a := range r.Actions {
+		if c, ok := a.(*recorder.SetColor); ok {
+			line = line || c.Color == (color.NRGBA{R: 0xff, A: 0x80})
+			scatter = scatter || c.Color == Color(1)
+		}
+	}
+	if !line || !scatter {
+		t.Errorf("series not drawn in expected colors: line=%t scatter=%t", line, scatter)
+	}
+
+	for _, bad := range []SeriesSpec{
+		{Type: "pie", Y: []float64{1}},
+		{Type: "line", X: []float64{1}, Y: []float64{1, 2}},
+		{Type: "bars", Y: []float64{1}, Color: "red"},
+	} {
+		if _, err := FromSpec(Spec{Series: []SeriesSpec{bad}}); err == nil {
+			t.Errorf("expected error for series %+v", bad)
+		}
+	}
+}
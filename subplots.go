@@ -0,0 +1,104 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"math"
+
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Subplots is a row of plots drawn side by side across
+// a canvas.  The canvas is divided so that the data areas
+// of the plots have the same width, and their tops and
+// bottoms are aligned.
+type Subplots struct {
+	// Plots are the plots, drawn from left to right.
+	Plots []*Plot
+
+	// ShareY specifies that the plots share the Y axis of
+	// the left-most plot.  Each plot is drawn with the union
+	// of the Y ranges of all of the plots, and with the Scale
+	// and ticks of the left-most plot.  Only the left-most
+	// plot draws the label and the tick labels of its Y axis,
+	// and the space left by the others widens the data areas.
+	ShareY bool
+
+	// Gap is the horizontal space between adjacent plots.
+	Gap vg.Length
+}
+
+// Draw draws the plots to the draw.Canvas.  The plots
+// themselves are not changed.  It returns a description
+// of the layout of each plot, as for Plot.DrawWithInfo.
+func (s Subplots) Draw(c draw.Canvas) []DrawInfo {
+	n := len(s.Plots)
+	if n == 0 {
+		return nil
+	}
+	plots := make([]*Plot, n)
+	for i, p := range s.Plots {
+		q := *p
+		plots[i] = &q
+	}
+	if s.ShareY {
+		first := plots[0]
+		min, max := math.Inf(1), math.Inf(-1)
+		for _, p := range plots {
+			min = math.Min(min, p.Y.Min)
+			max = math.Max(max, p.Y.Max)
+		}
+		for i, p := range plots {
+			p.Y.Min, p.Y.Max = min, max
+			if i == 0 {
+				continue
+			}
+			p.Y.Scale = first.Y.Scale
+			p.Y.Tick.Marker = first.Y.Tick.Marker
+			p.Y.Label.Text = ""
+			p.Y.Tick.HideLabels = true
+		}
+	}
+
+	// The space around the data area of each plot
+	// is found by laying it out on an equal share of
+	// the canvas.
+	share := c
+	share.Max.X = c.Min.X + (c.Size().X-s.Gap*vg.Length(n-1))/vg.Length(n)
+	left := make([]vg.Length, n)
+	right := make([]vg.Length, n)
+	var bottom, top, edges vg.Length
+	for i, p := range plots {
+		q := *p
+		da := q.DataCanvas(share)
+		left[i] = da.Min.X - share.Min.X
+		right[i] = share.Max.X - da.Max.X
+		edges += left[i] + right[i]
+		bottom = vg.Length(math.Max(float64(bottom), float64(da.Min.Y-share.Min.Y)))
+		top = vg.Length(math.Max(float64(top), float64(share.Max.Y-da.Max.Y)))
+	}
+	w := (c.Size().X - s.Gap*vg.Length(n-1) - edges) / vg.Length(n)
+	if w < 0 {
+		w = 0
+	}
+
+	infos := make([]DrawInfo, n)
+	x := c.Min.X
+	for i, p := range plots {
+		pc := c
+		pc.Min.X = x
+		pc.Max.X = x + left[i] + w + right[i]
+		x = pc.Max.X + s.Gap
+
+		// Plots with less space above or below their
+		// data areas are shrunk to align the data areas.
+		q := *p
+		da := q.DataCanvas(pc)
+		pc = pc.Crop(0, bottom-(da.Min.Y-pc.Min.Y), 0, -(top - (pc.Max.Y - da.Max.Y)))
+		infos[i] = p.DrawWithInfo(pc)
+	}
+	return infos
+}
@@ -0,0 +1,56 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+func TestSubplotsShareY(t *testing.T) {
+	newPlot := func(ymin, ymax float64) *plot.Plot {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("failed to create plot: %v", err)
+		}
+		p.X.Min, p.X.Max = 0, 1
+		p.Y.Min, p.Y.Max = ymin, ymax
+		p.Y.Label.Text = "Y"
+		return p
+	}
+
+	var widths [2]float64
+	for i, share := range []bool{false, true} {
+		left, right := newPlot(0, 10), newPlot(-5, 5)
+		s := plot.Subplots{Plots: []*plot.Plot{left, right}, ShareY: share, Gap: 10}
+		rec := recorder.New(72)
+		infos := s.Draw(draw.NewCanvas(rec, 300, 200))
+		if len(infos) != 2 {
+			t.Fatalf("unexpected number of layouts: got:%d want:2", len(infos))
+		}
+		a, b := infos[0].DataArea, infos[1].DataArea
+		if math.Abs(float64(a.Size().X-b.Size().X)) > 1e-9 {
+			t.Errorf("data areas differ in width with ShareY=%t: %v != %v", share, a.Size().X, b.Size().X)
+		}
+		if a.Min.Y != b.Min.Y || a.Max.Y != b.Max.Y {
+			t.Errorf("data areas not aligned with ShareY=%t: %v and %v", share, a, b)
+		}
+		if b.Min.X-a.Max.X <= 10 {
+			t.Errorf("data areas overlap the gap with ShareY=%t: %v and %v", share, a, b)
+		}
+		widths[i] = float64(a.Size().X)
+
+		if right.Y.Tick.HideLabels || right.Y.Label.Text != "Y" || right.Y.Min != -5 {
+			t.Errorf("Draw changed the right plot with ShareY=%t", share)
+		}
+	}
+	if widths[1] <= widths[0] {
+		t.Errorf("sharing the Y axis did not widen the data areas: got:%v want more than %v", widths[1], widths[0])
+	}
+}
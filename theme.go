@@ -0,0 +1,63 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import "image/color"
+
+// Theme is a set of colors for a plot.  The plotters
+// that have their default black lines and glyphs, such
+// as those made by the plotter package, are drawn with
+// the Foreground color of the theme, so that they may
+// be seen against the background of DarkTheme.
+type Theme struct {
+	// Background is the background color of the plot.
+	Background color.Color
+
+	// Foreground is the color of the text, the axis
+	// lines, the tick marks and the plotters that still
+	// have their default color, as for
+	// Plot.ForegroundColor.
+	Foreground color.Color
+
+	// Grid, if not nil, is the color of the grid lines
+	// of the axes.  Grid lines are still drawn only for
+	// axes whose GridStyle has a positive width.
+	Grid color.Color
+}
+
+// LightTheme returns the theme of dark text and
+// lines on a white background given by New, with
+// light gray grid lines.
+func LightTheme() Theme {
+	return Theme{
+		Background: color.White,
+		Foreground: color.Black,
+		Grid:       color.Gray{Y: 0xd3},
+	}
+}
+
+// DarkTheme returns a theme of light text and
+// lines on a dark gray background.
+func DarkTheme() Theme {
+	return Theme{
+		Background: color.Gray{Y: 0x20},
+		Foreground: color.Gray{Y: 0xe8},
+		Grid:       color.Gray{Y: 0x50},
+	}
+}
+
+// Apply sets the colors of the plot to those of the
+// theme.  The foreground color is applied when the plot
+// is drawn, so it also colors axes and plotters that are
+// added later, such as an axis added by AddTopX.  Apply
+// does not change the styles of the plot's plotters.
+func (t Theme) Apply(p *Plot) {
+	p.BackgroundColor = t.Background
+	p.ForegroundColor = t.Foreground
+	if t.Grid != nil {
+		p.X.GridStyle.Color = t.Grid
+		p.Y.GridStyle.Color = t.Grid
+	}
+}
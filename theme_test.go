@@ -0,0 +1,60 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/plotter"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+func TestDarkTheme(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("failed to create plot: %v", err)
+	}
+	p.Title.Text = "title"
+	p.X.Label.Text = "X"
+	p.X.Min, p.X.Max = 0, 1
+	p.Y.Min, p.Y.Max = 0, 1
+	p.Y.Label.Text = "Y"
+	p.Y.Label.Color = color.RGBA{R: 0xff, A: 0xff}
+	// The line has the default black style
+	// of the plotter package, and the points
+	// have a color set individually.
+	l, s, err := plotter.NewLinePoints(plotter.XYs{{0, 0}, {1, 1}})
+	if err != nil {
+		t.Fatalf("failed to create plotters: %v", err)
+	}
+	s.Color = color.RGBA{G: 0xff, A: 0xff}
+	p.Add(l, s)
+	p.Legend.Add("line", l, s)
+	theme := plot.DarkTheme()
+	theme.Apply(p)
+
+	r := recorder.New(72)
+	p.Draw(draw.NewCanvas(r, 200, 200))
+	got := make(map[color.Color]bool)
+	for _, a := range r.Actions {
+		if c, ok := a.(*recorder.SetColor); ok {
+			got[c.Color] = true
+		}
+	}
+	if got[color.Black] {
+		t.Error("default black not replaced by the theme")
+	}
+	for _, c := range []color.Color{theme.Background, theme.Foreground, p.Y.Label.Color, s.Color} {
+		if !got[c] {
+			t.Errorf("color %v not used", c)
+		}
+	}
+	if p.X.Label.Color != color.Black || l.Color != color.Black {
+		t.Errorf("theme changed the plot's styles: %v, %v", p.X.Label.Color, l.Color)
+	}
+}
@@ -14,7 +14,9 @@ import (
 
 // A Canvas is a vector graphics canvas along with
 // an associated Rectangle defining a section of the canvas
-// to which drawing should take place.
+// to which drawing should take place.  The embedded
+// Rectangle gives both corners of the section at once,
+// and its Min and Max fields are promoted to the Canvas.
 type Canvas struct {
 	vg.Canvas
 	Rectangle
@@ -27,6 +29,22 @@ type TextStyle struct {
 
 	// Font is the font description.
 	Font vg.Font
+
+	// RTL specifies that the text is written right-to-left.
+	// The lines of multi-line right-to-left text are aligned
+	// within the text's bounding box with their alignment
+	// reversed, so that, for example, left-anchored text has
+	// its lines flush with the right edge of its box.  The text
+	// is passed to the vg.Canvas in logical order.
+	RTL bool
+
+	// Fallback is a list of fonts, in order of preference,
+	// used to draw the runes of the text for which Font
+	// has no glyph.  Each such rune is drawn in the first
+	// of the fallback fonts that has a glyph for it, or in
+	// Font if none of them do.  The fallback fonts are
+	// drawn at their own sizes on the baselines of Font.
+	Fallback []vg.Font
 }
 
 // LineStyle describes what a line will look like.
@@ -52,6 +70,16 @@ type GlyphStyle struct {
 
 	// Shape draws the shape of the glyph.
 	Shape GlyphDrawer
+
+	// FillColor and LineStyle, if either is set, are
+	// the color used to fill the glyph and the style
+	// of its outline, in place of Color.  Glyphs that
+	// are only lines, such as PlusGlyph and CrossGlyph,
+	// ignore FillColor.  When neither is set the glyph
+	// is drawn in Color as either a filled shape or an
+	// outline according to its Shape.
+	FillColor color.Color
+	LineStyle LineStyle
 }
 
 // A GlyphDrawer wraps the DrawGlyph function.
@@ -91,6 +119,45 @@ func (g GlyphStyle) Rectangle() Rectangle {
 	}
 }
 
+// hasOutline returns true if the glyph has an outline
+// given by its LineStyle.
+func (g GlyphStyle) hasOutline() bool {
+	return g.LineStyle.Color != nil && g.LineStyle.Width > 0
+}
+
+// lineStyle returns the style of the lines of a
+// glyph that is only drawn with lines.
+func (g GlyphStyle) lineStyle() LineStyle {
+	if g.hasOutline() {
+		return g.LineStyle
+	}
+	return LineStyle{Color: g.Color, Width: vg.Points(0.5)}
+}
+
+// drawShape draws the closed path of a glyph.  If
+// the glyph has neither a FillColor nor an outline
+// then the path is filled if solid is true and
+// outlined otherwise.
+func (g GlyphStyle) drawShape(c *Canvas, p vg.Path, solid bool) {
+	if g.FillColor == nil && !g.hasOutline() {
+		if solid {
+			c.Fill(p)
+			return
+		}
+		c.SetLineStyle(g.lineStyle())
+		c.Stroke(p)
+		return
+	}
+	if g.FillColor != nil {
+		c.SetColor(g.FillColor)
+		c.Fill(p)
+	}
+	if g.hasOutline() {
+		c.SetLineStyle(g.LineStyle)
+		c.Stroke(p)
+	}
+}
+
 // CircleGlyph is a glyph that draws a solid circle.
 type CircleGlyph struct{}
 
@@ -100,7 +167,7 @@ func (CircleGlyph) DrawGlyph(c *Canvas, sty GlyphStyle, pt Point) {
 	p.Move(pt.X+sty.Radius, pt.Y)
 	p.Arc(pt.X, pt.Y, sty.Radius, 0, 2*math.Pi)
 	p.Close()
-	c.Fill(p)
+	sty.drawShape(c, p, true)
 }
 
 // RingGlyph is a glyph that draws the outline of a circle.
@@ -108,12 +175,11 @@ type RingGlyph struct{}
 
 // DrawGlyph implements the Glyph interface.
 func (RingGlyph) DrawGlyph(c *Canvas, sty GlyphStyle, pt Point) {
-	c.SetLineStyle(LineStyle{Color: sty.Color, Width: vg.Points(0.5)})
 	var p vg.Path
 	p.Move(pt.X+sty.Radius, pt.Y)
 	p.Arc(pt.X, pt.Y, sty.Radius, 0, 2*math.Pi)
 	p.Close()
-	c.Stroke(p)
+	sty.drawShape(c, p, false)
 }
 
 const (
@@ -127,7 +193,6 @@ type SquareGlyph struct{}
 
 // DrawGlyph implements the Glyph interface.
 func (SquareGlyph) DrawGlyph(c *Canvas, sty GlyphStyle, pt Point) {
-	c.SetLineStyle(LineStyle{Color: sty.Color, Width: vg.Points(0.5)})
 	x := (sty.Radius-sty.Radius*cosπover4)/2 + sty.Radius*cosπover4
 	var p vg.Path
 	p.Move(pt.X-x, pt.Y-x)
@@ -135,7 +200,7 @@ func (SquareGlyph) DrawGlyph(c *Canvas, sty GlyphStyle, pt Point) {
 	p.Line(pt.X+x, pt.Y+x)
 	p.Line(pt.X-x, pt.Y+x)
 	p.Close()
-	c.Stroke(p)
+	sty.drawShape(c, p, false)
 }
 
 // BoxGlyph is a glyph that draws a filled square.
@@ -150,7 +215,7 @@ func (BoxGlyph) DrawGlyph(c *Canvas, sty GlyphStyle, pt Point) {
 	p.Line(pt.X+x, pt.Y+x)
 	p.Line(pt.X-x, pt.Y+x)
 	p.Close()
-	c.Fill(p)
+	sty.drawShape(c, p, true)
 }
 
 // TriangleGlyph is a glyph that draws the outline of a triangle.
@@ -158,14 +223,13 @@ type TriangleGlyph struct{}
 
 // DrawGlyph implements the Glyph interface.
 func (TriangleGlyph) DrawGlyph(c *Canvas, sty GlyphStyle, pt Point) {
-	c.SetLineStyle(LineStyle{Color: sty.Color, Width: vg.Points(0.5)})
 	r := sty.Radius + (sty.Radius-sty.Radius*sinπover6)/2
 	var p vg.Path
 	p.Move(pt.X, pt.Y+r)
 	p.Line(pt.X-r*cosπover6, pt.Y-r*sinπover6)
 	p.Line(pt.X+r*cosπover6, pt.Y-r*sinπover6)
 	p.Close()
-	c.Stroke(p)
+	sty.drawShape(c, p, false)
 }
 
 // PyramidGlyph is a glyph that draws a filled triangle.
@@ -179,7 +243,7 @@ func (PyramidGlyph) DrawGlyph(c *Canvas, sty GlyphStyle, pt Point) {
 	p.Line(pt.X-r*cosπover6, pt.Y-r*sinπover6)
 	p.Line(pt.X+r*cosπover6, pt.Y-r*sinπover6)
 	p.Close()
-	c.Fill(p)
+	sty.drawShape(c, p, true)
 }
 
 // PlusGlyph is a glyph that draws a plus sign
@@ -187,7 +251,7 @@ type PlusGlyph struct{}
 
 // DrawGlyph implements the Glyph interface.
 func (PlusGlyph) DrawGlyph(c *Canvas, sty GlyphStyle, pt Point) {
-	c.SetLineStyle(LineStyle{Color: sty.Color, Width: vg.Points(0.5)})
+	c.SetLineStyle(sty.lineStyle())
 	r := sty.Radius
 	var p vg.Path
 	p.Move(pt.X, pt.Y+r)
@@ -204,7 +268,7 @@ type CrossGlyph struct{}
 
 // DrawGlyph implements the Glyph interface.
 func (CrossGlyph) DrawGlyph(c *Canvas, sty GlyphStyle, pt Point) {
-	c.SetLineStyle(LineStyle{Color: sty.Color, Width: vg.Points(0.5)})
+	c.SetLineStyle(sty.lineStyle())
 	r := sty.Radius * cosπover4
 	var p vg.Path
 	p.Move(pt.X-r, pt.Y-r)
@@ -235,10 +299,28 @@ func NewCanvas(c vg.Canvas, w, h vg.Length) Canvas {
 
 // Center returns the center point of the area
 func (c *Canvas) Center() Point {
-	return Point{
-		X: (c.Max.X-c.Min.X)/2 + c.Min.X,
-		Y: (c.Max.Y-c.Min.Y)/2 + c.Min.Y,
+	return c.Rectangle.Center()
+}
+
+// PushClip saves the state of the canvas, as by Push, and
+// then restricts subsequent drawing to the area enclosed by
+// the path, within any clipping region already in effect.
+// The clipping is removed by the corresponding PopClip.
+// PushClip returns false, and drawing is not clipped, if
+// the vg.Canvas does not implement vg.Clipper.
+func (c *Canvas) PushClip(p vg.Path) bool {
+	c.Push()
+	cl, ok := c.Canvas.(vg.Clipper)
+	if ok {
+		cl.Clip(p)
 	}
+	return ok
+}
+
+// PopClip restores the state of the canvas
+// saved by the corresponding PushClip.
+func (c *Canvas) PopClip() {
+	c.Pop()
 }
 
 // Contains returns true if the Canvas contains the point.
@@ -305,8 +387,10 @@ func (c *Canvas) SetLineStyle(sty LineStyle) {
 	c.SetLineDash(dashDots, sty.DashOffs)
 }
 
-// StrokeLines draws a line connecting a set of points
-// in the given Canvas.
+// StrokeLines draws a line connecting each set of points
+// in the given Canvas.  All of the lines are batched into a
+// single path so that the underlying vg.Canvas is only
+// asked to stroke once, regardless of the number of lines.
 func (c *Canvas) StrokeLines(sty LineStyle, lines ...[]Point) {
 	if len(lines) == 0 {
 		return
@@ -314,17 +398,20 @@ func (c *Canvas) StrokeLines(sty LineStyle, lines ...[]Point) {
 
 	c.SetLineStyle(sty)
 
+	var p vg.Path
 	for _, l := range lines {
 		if len(l) == 0 {
 			continue
 		}
-		var p vg.Path
 		p.Move(l[0].X, l[0].Y)
 		for _, pt := range l[1:] {
 			p.Line(pt.X, pt.Y)
 		}
-		c.Stroke(p)
 	}
+	if len(p) == 0 {
+		return
+	}
+	c.Stroke(p)
 }
 
 // StrokeLine2 draws a line between two points in the given
@@ -405,6 +492,16 @@ func clipLine(in func(Point, Point) bool, clip, norm Point, pts []Point) (lines
 	return
 }
 
+// Clear fills the whole of the canvas's rectangle with the
+// given color, so that a canvas may be reused, for example
+// for the frames of an animation.  An opaque color covers
+// all of the earlier drawing within the rectangle, while a
+// translucent one is blended over it as by Fill.
+func (c *Canvas) Clear(clr color.Color) {
+	c.SetColor(clr)
+	c.Fill(c.Rectangle.Path())
+}
+
 // FillPolygon fills a polygon with the given color.
 func (c *Canvas) FillPolygon(clr color.Color, pts []Point) {
 	if len(pts) == 0 {
@@ -505,6 +602,8 @@ func isect(p0, p1, clip, norm Point) Point {
 // The text is offset by its width times xalign and
 // its height times yalign.  x and y give the bottom
 // left corner of the text befor e it is offset.
+// If sty.RTL is true then the lines of the text are
+// aligned as described by TextStyle.
 func (c *Canvas) FillText(sty TextStyle, x, y vg.Length, xalign, yalign float64, txt string) {
 	txt = strings.TrimRight(txt, "\n")
 	if len(txt) == 0 {
@@ -516,10 +615,167 @@ func (c *Canvas) FillText(sty TextStyle, x, y vg.Length, xalign, yalign float64,
 	ht := sty.Height(txt)
 	y += ht*vg.Length(yalign) - sty.Font.Extents().Ascent
 	nl := textNLines(txt)
+	wd := sty.Width(txt)
 	for i, line := range strings.Split(txt, "\n") {
-		xoffs := vg.Length(xalign) * sty.Font.Width(line)
+		runs := sty.runs(line)
+		w := runsWidth(runs)
+		xoffs := vg.Length(xalign) * w
+		if sty.RTL {
+			xoffs = vg.Length(xalign)*wd + vg.Length(1+xalign)*(wd-w)
+		}
 		n := vg.Length(nl - i)
-		c.FillString(sty.Font, x+xoffs, y+n*sty.Font.Size, line)
+		if len(runs) == 1 {
+			c.FillString(runs[0].font, x+xoffs, y+n*sty.Font.Size, line)
+			continue
+		}
+		// The runs of right-to-left text are
+		// laid out from the right end of the line.
+		xr := x + xoffs
+		if sty.RTL {
+			xr += w
+		}
+		for _, r := range runs {
+			rw := r.font.Width(r.text)
+			if sty.RTL {
+				xr -= rw
+			}
+			c.FillString(r.font, xr, y+n*sty.Font.Size, r.text)
+			if !sty.RTL {
+				xr += rw
+			}
+		}
+	}
+}
+
+// textRun is a run of text drawn in a single font.
+type textRun struct {
+	font vg.Font
+	text string
+}
+
+// runs returns the line of text split into runs
+// of runes that are drawn in the same font.
+func (sty TextStyle) runs(line string) []textRun {
+	if len(sty.Fallback) == 0 {
+		return []textRun{{font: sty.Font, text: line}}
+	}
+	var runs []textRun
+	start, cur := 0, -1
+	for i, r := range line {
+		f := sty.fontFor(r)
+		if f != cur && i > start {
+			runs = append(runs, textRun{font: sty.font(cur), text: line[start:i]})
+			start = i
+		}
+		cur = f
+	}
+	return append(runs, textRun{font: sty.font(cur), text: line[start:]})
+}
+
+// fontFor returns the index of the fallback font used
+// to draw the rune r, or -1 if r is drawn in Font.
+func (sty TextStyle) fontFor(r rune) int {
+	if sty.Font.HasGlyph(r) {
+		return -1
+	}
+	for i := range sty.Fallback {
+		if sty.Fallback[i].HasGlyph(r) {
+			return i
+		}
+	}
+	return -1
+}
+
+// font returns the fallback font with the given
+// index, or Font if the index is -1.
+func (sty TextStyle) font(i int) vg.Font {
+	if i < 0 {
+		return sty.Font
+	}
+	return sty.Fallback[i]
+}
+
+// runsWidth returns the total width of the runs.
+func runsWidth(runs []textRun) (w vg.Length) {
+	for _, r := range runs {
+		w += r.font.Width(r.text)
+	}
+	return w
+}
+
+// FillTextRotated fills lines of text in the draw area,
+// rotated counter-clockwise by the given angle, in radians,
+// about the point x, y.  The text is aligned as by FillText
+// relative to its own rotated bounding box, so the point
+// x, y stays at the same position relative to the text
+// whatever the angle.
+func (c *Canvas) FillTextRotated(sty TextStyle, x, y vg.Length, xalign, yalign, angle float64, txt string) {
+	c.Push()
+	c.Translate(x, y)
+	c.Rotate(angle)
+	c.FillText(sty, 0, 0, xalign, yalign, txt)
+	c.Pop()
+}
+
+// FillTextOnPath fills the text along the line through
+// the points, as for the inline labels of contour lines.
+// The text is centered on the length of the line, and each
+// character is centered vertically on the line and rotated
+// to its direction at the middle of the character.  A line
+// running to the left is followed from its end, so that
+// the text is not drawn upside down.
+//
+// Text with more than one line, or that is longer than the
+// line, is instead drawn horizontally, centered on the
+// middle of the line.
+func (c *Canvas) FillTextOnPath(sty TextStyle, pts []Point, txt string) {
+	txt = strings.TrimRight(txt, "\n")
+	if len(txt) == 0 || len(pts) == 0 {
+		return
+	}
+	if pts[len(pts)-1].X < pts[0].X {
+		rev := make([]Point, len(pts))
+		for i, p := range pts {
+			rev[len(pts)-1-i] = p
+		}
+		pts = rev
+	}
+
+	// at returns the point at the distance d along
+	// the line and the direction of the line there.
+	var total vg.Length
+	lens := make([]vg.Length, len(pts)-1)
+	for i := range lens {
+		lens[i] = vg.Length(math.Hypot(float64(pts[i+1].X-pts[i].X), float64(pts[i+1].Y-pts[i].Y)))
+		total += lens[i]
+	}
+	at := func(d vg.Length) (Point, float64) {
+		for i, l := range lens {
+			if d > l && i < len(lens)-1 || l == 0 {
+				d -= l
+				continue
+			}
+			p0, p1 := pts[i], pts[i+1]
+			f := d / l
+			return Point{p0.X + (p1.X-p0.X)*f, p0.Y + (p1.Y-p0.Y)*f},
+				math.Atan2(float64(p1.Y-p0.Y), float64(p1.X-p0.X))
+		}
+		return pts[len(pts)-1], 0
+	}
+
+	w := sty.Width(txt)
+	if textNLines(txt) > 1 || w > total {
+		mid, _ := at(total / 2)
+		c.FillText(sty, mid.X, mid.Y, -0.5, -0.5, txt)
+		return
+	}
+	d := (total - w) / 2
+	for _, r := range txt {
+		ch := string(r)
+		cw := sty.Width(ch)
+		p, angle := at(d + cw/2)
+		c.FillTextRotated(sty, p.X, p.Y, -0.5, -0.5, angle, ch)
+		d += cw
 	}
 }
 
@@ -528,7 +784,7 @@ func (c *Canvas) FillText(sty TextStyle, x, y vg.Length, xalign, yalign float64,
 func (sty TextStyle) Width(txt string) (max vg.Length) {
 	txt = strings.TrimRight(txt, "\n")
 	for _, line := range strings.Split(txt, "\n") {
-		if w := sty.Font.Width(line); w > max {
+		if w := runsWidth(sty.runs(line)); w > max {
 			max = w
 		}
 	}
@@ -569,10 +825,25 @@ func textNLines(txt string) int {
 
 // A Rectangle represents a rectangular region of 2d space.
 type Rectangle struct {
+	// Min is the bottom left corner of the
+	// rectangle, with the least X and Y.
 	Min Point
+
+	// Max is the top right corner of the
+	// rectangle, with the greatest X and Y.
+	// Points on the right and top edges are
+	// within the rectangle.
 	Max Point
 }
 
+// Center returns the center point of a Rectangle.
+func (r Rectangle) Center() Point {
+	return Point{
+		X: (r.Max.X-r.Min.X)/2 + r.Min.X,
+		Y: (r.Max.Y-r.Min.Y)/2 + r.Min.Y,
+	}
+}
+
 // Size returns the width and height of a Rectangle.
 func (r Rectangle) Size() Point {
 	return Point{
@@ -592,6 +863,15 @@ func (r Rectangle) Path() (p vg.Path) {
 	return
 }
 
+// CirclePath returns the path of a circle
+// with the given center and radius.
+func CirclePath(center Point, r vg.Length) (p vg.Path) {
+	p.Move(center.X+r, center.Y)
+	p.Arc(center.X, center.Y, r, 0, 2*math.Pi)
+	p.Close()
+	return
+}
+
 // A Point is a location in 2d space.
 //
 // Points are used for drawing, not for data.  For
@@ -2,11 +2,14 @@ package draw
 
 import (
 	"image/color"
+	"io/ioutil"
+	"math"
 	"reflect"
 	"testing"
 
 	"github.com/gonum/plot/vg"
 	"github.com/gonum/plot/vg/recorder"
+	"github.com/gonum/plot/vg/vgsvg"
 )
 
 func TestCrop(t *testing.T) {
@@ -56,3 +59,381 @@ func TestCrop(t *testing.T) {
 		t.Errorf(str, r1.Actions, r2.Actions)
 	}
 }
+
+func TestStrokeLinesBatch(t *testing.T) {
+	r := recorder.New(96)
+	c := NewCanvas(r, 10, 10)
+	c.StrokeLines(LineStyle{Width: 1},
+		[]Point{{0, 0}, {1, 1}},
+		nil,
+		[]Point{{2, 2}, {3, 3}, {4, 4}},
+	)
+	var strokes []*recorder.Stroke
+	for _, a := range r.Actions {
+		if s, ok := a.(*recorder.Stroke); ok {
+			strokes = append(strokes, s)
+		}
+	}
+	if len(strokes) != 1 {
+		t.Fatalf("unexpected number of strokes: got:%d want:1", len(strokes))
+	}
+	want := vg.Path{
+		{Type: vg.MoveComp, X: 0, Y: 0},
+		{Type: vg.LineComp, X: 1, Y: 1},
+		{Type: vg.MoveComp, X: 2, Y: 2},
+		{Type: vg.LineComp, X: 3, Y: 3},
+		{Type: vg.LineComp, X: 4, Y: 4},
+	}
+	if !reflect.DeepEqual(strokes[0].Path, want) {
+		t.Errorf("unexpected path: got:%v want:%v", strokes[0].Path, want)
+	}
+}
+
+func TestFillTextRTL(t *testing.T) {
+	font, err := vg.MakeFont("Times-Roman", 12)
+	if err != nil {
+		t.Fatalf("failed to create font: %v", err)
+	}
+	const txt = "a\naaaa"
+	short, long := font.Width("a"), font.Width("aaaa")
+	tests := []struct {
+		rtl    bool
+		xalign float64
+		want   []vg.Length
+	}{
+		{rtl: false, xalign: 0, want: []vg.Length{10, 10}},
+		{rtl: false, xalign: -1, want: []vg.Length{10 - short, 10 - long}},
+		{rtl: true, xalign: 0, want: []vg.Length{10 + long - short, 10}},
+		{rtl: true, xalign: -1, want: []vg.Length{10 - long, 10 - long}},
+		{rtl: true, xalign: -0.5, want: []vg.Length{10 - short/2, 10 - long/2}},
+	}
+	for _, test := range tests {
+		r := recorder.New(96)
+		c := NewCanvas(r, 100, 100)
+		c.FillText(TextStyle{Font: font, RTL: test.rtl}, 10, 10, test.xalign, 0, txt)
+		var got []vg.Length
+		for _, a := range r.Actions {
+			if s, ok := a.(*recorder.FillString); ok {
+				got = append(got, s.X)
+			}
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected line positions for rtl=%t xalign=%g: got:%v want:%v",
+				test.rtl, test.xalign, got, test.want)
+		}
+	}
+}
+
+func TestFillTextFallback(t *testing.T) {
+	helv, err := vg.MakeFont("Helvetica", 12)
+	if err != nil {
+		t.Fatalf("failed to create font: %v", err)
+	}
+	times, err := vg.MakeFont("Times-Roman", 10)
+	if err != nil {
+		t.Fatalf("failed to create font: %v", err)
+	}
+	if helv.HasGlyph('Ω') || !times.HasGlyph('Ω') {
+		t.Fatal("unexpected glyph coverage of test fonts")
+	}
+
+	sty := TextStyle{Font: helv, Fallback: []vg.Font{times}}
+	const txt = "R = 5Ω"
+	if got, want := sty.Width(txt), helv.Width("R = 5")+times.Width("Ω"); got != want {
+		t.Errorf("unexpected width: got:%v want:%v", got, want)
+	}
+
+	r := recorder.New(96)
+	c := NewCanvas(r, 100, 100)
+	c.FillText(sty, 10, 10, 0, 0, txt)
+	type run struct {
+		font   string
+		x      vg.Length
+		string string
+	}
+	var got []run
+	for _, a := range r.Actions {
+		if s, ok := a.(*recorder.FillString); ok {
+			got = append(got, run{font: s.Font, x: s.X, string: s.String})
+		}
+	}
+	want := []run{
+		{font: "Helvetica", x: 10, string: "R = 5"},
+		{font: "Times-Roman", x: 10 + helv.Width("R = 5"), string: "Ω"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected runs: got:%v want:%v", got, want)
+	}
+}
+
+func TestGlyphFillAndOutline(t *testing.T) {
+	outline := LineStyle{Color: color.Black, Width: 1}
+	tests := []struct {
+		sty           GlyphStyle
+		fills, stroke int
+	}{
+		{sty: GlyphStyle{Color: color.Black, Shape: CircleGlyph{}}, fills: 1},
+		{sty: GlyphStyle{Color: color.Black, Shape: RingGlyph{}}, stroke: 1},
+		{sty: GlyphStyle{Shape: CircleGlyph{}, FillColor: color.White, LineStyle: outline}, fills: 1, stroke: 1},
+		{sty: GlyphStyle{Shape: RingGlyph{}, FillColor: color.White}, fills: 1},
+		{sty: GlyphStyle{Shape: BoxGlyph{}, LineStyle: outline}, stroke: 1},
+		{sty: GlyphStyle{Shape: PlusGlyph{}, FillColor: color.White, LineStyle: outline}, stroke: 2},
+	}
+	for _, test := range tests {
+		test.sty.Radius = 2
+		r := recorder.New(96)
+		c := NewCanvas(r, 10, 10)
+		c.DrawGlyph(test.sty, Point{5, 5})
+		var fills, strokes int
+		for _, a := range r.Actions {
+			switch a.(type) {
+			case *recorder.Fill:
+				fills++
+			case *recorder.Stroke:
+				strokes++
+			}
+		}
+		if fills != test.fills || strokes != test.stroke {
+			t.Errorf("unexpected drawing of %T: got:%d fills and %d strokes want:%d fills and %d strokes",
+				test.sty.Shape, fills, strokes, test.fills, test.stroke)
+		}
+	}
+}
+
+func TestFillTextRotated(t *testing.T) {
+	font, err := vg.MakeFont("Times-Roman", 12)
+	if err != nil {
+		t.Fatalf("failed to create font: %v", err)
+	}
+	sty := TextStyle{Font: font}
+
+	// The rotated text must be drawn as the unrotated
+	// text is when translated to the origin.
+	r := recorder.New(96)
+	c := NewCanvas(r, 100, 100)
+	c.FillText(sty, 0, 0, -0.5, -0.5, "text")
+	want := r.Actions[len(r.Actions)-1].(*recorder.FillString)
+
+	r.Reset()
+	c.FillTextRotated(sty, 30, 40, -0.5, -0.5, math.Pi/4, "text")
+	var calls []string
+	var got *recorder.FillString
+	for _, a := range r.Actions {
+		switch a := a.(type) {
+		case *recorder.Push, *recorder.Pop:
+			calls = append(calls, reflect.TypeOf(a).Elem().Name())
+		case *recorder.Translate:
+			calls = append(calls, "Translate")
+			if a.X != 30 || a.Y != 40 {
+				t.Errorf("unexpected translation: got:(%v,%v) want:(30,40)", a.X, a.Y)
+			}
+		case *recorder.Rotate:
+			calls = append(calls, "Rotate")
+			if a.Angle != math.Pi/4 {
+				t.Errorf("unexpected rotation: got:%v want:%v", a.Angle, math.Pi/4)
+			}
+		case *recorder.FillString:
+			calls = append(calls, "FillString")
+			got = a
+		}
+	}
+	if wantCalls := []string{"Push", "Translate", "Rotate", "FillString", "Pop"}; !reflect.DeepEqual(calls, wantCalls) {
+		t.Errorf("unexpected calls: got:%v want:%v", calls, wantCalls)
+	}
+	if got == nil || got.X != want.X || got.Y != want.Y {
+		t.Errorf("unexpected text position: got:%+v want:%+v", got, want)
+	}
+}
+
+// BenchmarkStrokeLinesSVG strokes a set of tick-mark sized
+// lines to an SVG canvas.  The number of bytes processed
+// per operation is the size of the resulting SVG file.
+func BenchmarkStrokeLinesSVG(b *testing.B) {
+	lines := make([][]Point, 100)
+	for i := range lines {
+		x := vg.Length(i)
+		lines[i] = []Point{{x, 0}, {x, 8}}
+	}
+	ls := LineStyle{Color: color.Black, Width: 1}
+	for i := 0; i < b.N; i++ {
+		svg := vgsvg.New(4*vg.Inch, 4*vg.Inch)
+		c := New(svg)
+		c.StrokeLines(ls, lines...)
+		n, err := svg.WriteTo(ioutil.Discard)
+		if err != nil {
+			b.Fatalf("failed to write SVG: %v", err)
+		}
+		b.SetBytes(n)
+	}
+}
+
+func TestRectangleCenter(t *testing.T) {
+	r := Rectangle{Min: Point{1, 2}, Max: Point{5, 10}}
+	if got, want := r.Center(), (Point{3, 6}); got != want {
+		t.Errorf("unexpected rectangle center: got:%v want:%v", got, want)
+	}
+	c := Canvas{Rectangle: r}
+	if got, want := c.Center(), r.Center(); got != want {
+		t.Errorf("unexpected canvas center: got:%v want:%v", got, want)
+	}
+}
+
+func TestFillHatch(t *testing.T) {
+	square := []Point{{1, 1}, {1, 9}, {9, 9}, {9, 1}}
+	ls := LineStyle{Color: color.Black, Width: 0.5}
+	for _, test := range []struct {
+		style        HatchStyle
+		lines, spots int
+	}{
+		{style: NoHatch},
+		{style: DiagonalHatch, lines: 5},
+		{style: CrossHatch, lines: 11},
+		{style: DotHatch, spots: 16},
+	} {
+		r := recorder.New(72)
+		c := NewCanvas(r, 10, 10)
+		c.FillHatch(Hatch{Style: test.style, Spacing: 2, LineStyle: ls}, square)
+
+		var lines, spots int
+		for _, a := range r.Actions {
+			switch a := a.(type) {
+			case *recorder.Stroke:
+				for _, comp := range a.Path {
+					if comp.Type != vg.LineComp {
+						continue
+					}
+					lines++
+					if comp.X < 1-1e-9 || comp.X > 9+1e-9 || comp.Y < 1-1e-9 || comp.Y > 9+1e-9 {
+						t.Errorf("hatch line for style %d leaves the region: %v", test.style, comp)
+					}
+				}
+			case *recorder.Fill:
+				for _, comp := range a.Path {
+					if comp.Type == vg.ArcComp {
+						spots++
+					}
+				}
+			}
+		}
+		if lines != test.lines || spots != test.spots {
+			t.Errorf("unexpected hatching for style %d: got %d lines and %d dots, want %d and %d",
+				test.style, lines, spots, test.lines, test.spots)
+		}
+	}
+}
+
+func TestPushClip(t *testing.T) {
+	r := recorder.New(72)
+	c := NewCanvas(r, 100, 100)
+	circle := CirclePath(c.Center(), 50)
+	if !c.PushClip(circle) {
+		t.Error("expected recorder to support clipping")
+	}
+	c.Fill(c.Rectangle.Path())
+	c.PopClip()
+	var got []string
+	for _, a := range r.Actions {
+		switch a.(type) {
+		case *recorder.Push:
+			got = append(got, "push")
+		case *recorder.Clip:
+			got = append(got, "clip")
+		case *recorder.Fill:
+			got = append(got, "fill")
+		case *recorder.Pop:
+			got = append(got, "pop")
+		}
+	}
+	if want := []string{"push", "clip", "fill", "pop"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected actions: got:%v want:%v", got, want)
+	}
+
+	// noClip hides the Clip method of the recorder.
+	type noClip struct{ vg.Canvas }
+	c = NewCanvas(noClip{recorder.New(72)}, 100, 100)
+	if c.PushClip(circle) {
+		t.Error("unexpected support for clipping")
+	}
+	c.PopClip()
+}
+
+func TestFillTextOnPath(t *testing.T) {
+	font, err := vg.MakeFont("Times-Roman", 12)
+	if err != nil {
+		t.Fatalf("failed to create font: %v", err)
+	}
+	sty := TextStyle{Font: font}
+
+	// A right angle running to the left is followed
+	// from its end, up and then to the right.
+	pts := []Point{{200, 100}, {0, 100}, {0, 0}}
+	r := recorder.New(96)
+	c := NewCanvas(r, 300, 300)
+	c.FillTextOnPath(sty, pts, "ab  cd")
+	var angles []float64
+	var strs []string
+	for _, a := range r.Actions {
+		switch a := a.(type) {
+		case *recorder.Rotate:
+			angles = append(angles, a.Angle)
+		case *recorder.FillString:
+			strs = append(strs, a.String)
+		}
+	}
+	if want := []string{"a", "b", " ", " ", "c", "d"}; !reflect.DeepEqual(strs, want) {
+		t.Fatalf("unexpected characters: got:%q want:%q", strs, want)
+	}
+	// The short text is centered on the 300pt line,
+	// which puts it on the rightward part.
+	if len(angles) != len(strs) {
+		t.Fatalf("unexpected rotations: got:%v for %d characters", angles, len(strs))
+	}
+	for i, a := range angles {
+		if a != 0 {
+			t.Errorf("unexpected angle of character %d: got:%g want:0", i, a)
+		}
+	}
+
+	r = recorder.New(96)
+	c = NewCanvas(r, 300, 300)
+	pts = []Point{{0, 0}, {10, 10}}
+	c.FillTextOnPath(sty, pts, "too long for the line")
+	for _, a := range r.Actions {
+		switch a := a.(type) {
+		case *recorder.Rotate:
+			t.Errorf("unexpected rotation for text longer than the line: %v", a.Angle)
+		case *recorder.FillString:
+			if a.String != "too long for the line" {
+				t.Errorf("unexpected fallback text: %q", a.String)
+			}
+		}
+	}
+
+	r = recorder.New(96)
+	c = NewCanvas(r, 300, 300)
+	c.FillTextOnPath(sty, []Point{{0, 0}, {0, 100}}, "up")
+	for _, a := range r.Actions {
+		if a, ok := a.(*recorder.Rotate); ok && math.Abs(a.Angle-math.Pi/2) > 1e-12 {
+			t.Errorf("unexpected angle along a vertical line: got:%g want:π/2", a.Angle)
+		}
+	}
+}
+
+func TestClear(t *testing.T) {
+	r := recorder.New(96)
+	c := NewCanvas(r, 100, 100).Crop(10, 20, -5, -5)
+	c.Clear(color.White)
+	if len(r.Actions) != 2 {
+		t.Fatalf("unexpected actions: %v", r.Actions)
+	}
+	if sc, ok := r.Actions[0].(*recorder.SetColor); !ok || sc.Color != color.White {
+		t.Errorf("unexpected first action: got:%v want:SetColor(white)", r.Actions[0])
+	}
+	f, ok := r.Actions[1].(*recorder.Fill)
+	if !ok {
+		t.Fatalf("unexpected second action: got:%v want:Fill", r.Actions[1])
+	}
+	if !reflect.DeepEqual(f.Path, c.Rectangle.Path()) {
+		t.Errorf("unexpected fill: got:%v want:%v", f.Path, c.Rectangle.Path())
+	}
+}
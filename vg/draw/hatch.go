@@ -0,0 +1,168 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"math"
+	"sort"
+
+	"github.com/gonum/plot/vg"
+)
+
+// HatchStyle is the pattern used to hatch a region.
+type HatchStyle int
+
+const (
+	// NoHatch draws no pattern.
+	NoHatch HatchStyle = iota
+
+	// DiagonalHatch draws parallel lines
+	// rising from left to right at 45°.
+	DiagonalHatch
+
+	// CrossHatch draws diagonal lines in
+	// both directions.
+	CrossHatch
+
+	// DotHatch draws a square grid of dots.
+	DotHatch
+)
+
+// Hatch specifies how a region is hatched.  Hatching
+// is an alternative to a translucent fill that remains
+// distinct when printed in grayscale.
+type Hatch struct {
+	// Style is the pattern of the hatching.
+	Style HatchStyle
+
+	// Spacing is the distance between adjacent
+	// lines or dots of the pattern.  If Spacing is
+	// zero then a spacing of 4 points is used.
+	Spacing vg.Length
+
+	// LineStyle is the style of the hatch lines.
+	// The dots of DotHatch are filled with its
+	// color and have a radius of its width.
+	LineStyle
+}
+
+// FillHatch hatches the polygon given by pts using the
+// given Hatch.  The pattern is anchored to the origin
+// of the canvas, so adjacent regions hatched with the
+// same Hatch have aligned patterns.
+func (c *Canvas) FillHatch(h Hatch, pts []Point) {
+	if len(pts) < 3 {
+		return
+	}
+	sp := h.Spacing
+	if sp == 0 {
+		sp = vg.Points(4)
+	}
+	if sp < 0 {
+		return
+	}
+	switch h.Style {
+	case DiagonalHatch:
+		c.StrokeLines(h.LineStyle, hatchLines(pts, math.Pi/4, sp)...)
+	case CrossHatch:
+		lines := hatchLines(pts, math.Pi/4, sp)
+		lines = append(lines, hatchLines(pts, 3*math.Pi/4, sp)...)
+		c.StrokeLines(h.LineStyle, lines...)
+	case DotHatch:
+		if h.Color == nil || h.Width <= 0 {
+			return
+		}
+		var p vg.Path
+		min, max := pts[0], pts[0]
+		for _, pt := range pts[1:] {
+			if pt.X < min.X {
+				min.X = pt.X
+			}
+			if pt.Y < min.Y {
+				min.Y = pt.Y
+			}
+			if pt.X > max.X {
+				max.X = pt.X
+			}
+			if pt.Y > max.Y {
+				max.Y = pt.Y
+			}
+		}
+		for y := vg.Length(math.Ceil(float64(min.Y/sp))) * sp; y <= max.Y; y += sp {
+			for x := vg.Length(math.Ceil(float64(min.X/sp))) * sp; x <= max.X; x += sp {
+				if !insidePolygon(pts, Point{x, y}) {
+					continue
+				}
+				p.Move(x+h.Width, y)
+				p.Arc(x, y, h.Width, 0, 2*math.Pi)
+				p.Close()
+			}
+		}
+		if len(p) > 0 {
+			c.SetColor(h.Color)
+			c.Fill(p)
+		}
+	}
+}
+
+// hatchLines returns the segments of the lines at the given
+// angle, spaced sp apart, that lie within the polygon pts
+// by the even-odd rule.
+func hatchLines(pts []Point, angle float64, sp vg.Length) [][]Point {
+	// d is the direction of the lines and n is their normal.
+	dx, dy := math.Cos(angle), math.Sin(angle)
+	nx, ny := -dy, dx
+	proj := func(p Point, x, y float64) float64 {
+		return float64(p.X)*x + float64(p.Y)*y
+	}
+
+	smin, smax := math.Inf(1), math.Inf(-1)
+	for _, p := range pts {
+		s := proj(p, nx, ny)
+		smin = math.Min(smin, s)
+		smax = math.Max(smax, s)
+	}
+
+	var lines [][]Point
+	step := float64(sp)
+	for s := math.Ceil(smin/step) * step; s <= smax; s += step {
+		var us []float64
+		for i := range pts {
+			a, b := pts[i], pts[(i+1)%len(pts)]
+			sa, sb := proj(a, nx, ny)-s, proj(b, nx, ny)-s
+			if (sa < 0) == (sb < 0) {
+				continue
+			}
+			t := sa / (sa - sb)
+			x := float64(a.X) + t*float64(b.X-a.X)
+			y := float64(a.Y) + t*float64(b.Y-a.Y)
+			us = append(us, x*dx+y*dy)
+		}
+		sort.Float64s(us)
+		for i := 0; i+1 < len(us); i += 2 {
+			lines = append(lines, []Point{
+				{vg.Length(us[i]*dx + s*nx), vg.Length(us[i]*dy + s*ny)},
+				{vg.Length(us[i+1]*dx + s*nx), vg.Length(us[i+1]*dy + s*ny)},
+			})
+		}
+	}
+	return lines
+}
+
+// insidePolygon returns whether p is within the
+// polygon pts by the even-odd rule.
+func insidePolygon(pts []Point, p Point) bool {
+	in := false
+	for i := range pts {
+		a, b := pts[i], pts[(i+1)%len(pts)]
+		if (a.Y > p.Y) == (b.Y > p.Y) {
+			continue
+		}
+		if x := a.X + (p.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y); p.X < x {
+			in = !in
+		}
+	}
+	return in
+}
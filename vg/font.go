@@ -145,6 +145,14 @@ type FontExtents struct {
 	Height Length
 }
 
+// Ems returns the length of n ems in the font.
+// An em is the size of the font, so lengths given
+// in ems keep their proportion to the text when
+// the font size changes.
+func (f *Font) Ems(n float64) Length {
+	return Length(n) * f.Size
+}
+
 // Extents returns the FontExtents for a font.
 func (f *Font) Extents() FontExtents {
 	bounds := f.font.Bounds(f.Font().FUnitsPerEm())
@@ -156,6 +164,12 @@ func (f *Font) Extents() FontExtents {
 	}
 }
 
+// HasGlyph returns whether the font has a glyph
+// for the given rune.
+func (f *Font) HasGlyph(r rune) bool {
+	return f.font.Index(r) != 0
+}
+
 // Width returns width of a string when drawn using the font.
 func (f *Font) Width(s string) Length {
 	// scale converts truetype.FUnit to float64
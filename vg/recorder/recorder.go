@@ -379,6 +379,37 @@ func (a *Fill) callerLocation() *callerLocation {
 	return &a.l
 }
 
+// Clip corresponds to the vg.Clipper.Clip method.
+type Clip struct {
+	Path vg.Path
+
+	l callerLocation
+}
+
+var _ vg.Clipper = (*Canvas)(nil)
+
+// Clip implements the Clip method of the vg.Clipper interface.
+func (c *Canvas) Clip(path vg.Path) {
+	c.append(&Clip{Path: append(vg.Path(nil), path...)})
+}
+
+// Call returns the method call that generated the action.
+func (a *Clip) Call() string {
+	return fmt.Sprintf("%sClip(%#v)", a.l, a.Path)
+}
+
+// ApplyTo applies the action to the given vg.Canvas
+// if it implements vg.Clipper.
+func (a *Clip) ApplyTo(c vg.Canvas) {
+	if c, ok := c.(vg.Clipper); ok {
+		c.Clip(a.Path)
+	}
+}
+
+func (a *Clip) callerLocation() *callerLocation {
+	return &a.l
+}
+
 // FillString corresponds to the vg.Canvas.FillString method.
 type FillString struct {
 	Font   string
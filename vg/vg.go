@@ -38,6 +38,12 @@ type Canvas interface {
 	//
 	// The initial color is black.  If SetColor is
 	// called with a nil color then black is used.
+	//
+	// Strokes, fills and text are composited over
+	// what is already drawn using the alpha of the
+	// color by the raster, SVG and PDF backends.
+	// The EPS backend does not support transparency
+	// and draws the color opaquely.
 	SetColor(color.Color)
 
 	// Rotate applies a rotation transform to the
@@ -79,6 +85,19 @@ type Canvas interface {
 	DPI() float64
 }
 
+// Clipper wraps the Clip method.  It is implemented
+// by the Canvases that can restrict drawing to a region:
+// the EPS and SVG backends and the recorder.  The raster,
+// PDF and X11 backends do not implement Clipper.
+type Clipper interface {
+	// Clip intersects the clipping region with
+	// the area enclosed by the given path, so that
+	// subsequent drawing is only visible within it.
+	// The clipping region is part of the state saved
+	// by Push and restored by Pop.
+	Clip(Path)
+}
+
 // CanvasSizer is a Canvas with a defined size.
 type CanvasSizer interface {
 	Canvas
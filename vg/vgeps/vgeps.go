@@ -148,6 +148,12 @@ func (e *Canvas) Fill(path vg.Path) {
 	e.buf.WriteString("fill\n")
 }
 
+// Clip implements the vg.Clipper interface.
+func (e *Canvas) Clip(path vg.Path) {
+	e.trace(path)
+	e.buf.WriteString("clip\nnewpath\n")
+}
+
 func (e *Canvas) trace(path vg.Path) {
 	e.buf.WriteString("newpath\n")
 	for _, comp := range path {
@@ -18,6 +18,7 @@ import (
 	"io"
 
 	"code.google.com/p/draw2d/draw2d"
+	"code.google.com/p/freetype-go/freetype/raster"
 	"github.com/gonum/plot/vg"
 	"golang.org/x/image/tiff"
 )
@@ -35,17 +36,29 @@ type Canvas struct {
 
 	// width is the current line width.
 	width vg.Length
+
+	// painter is the painter used to draw to
+	// the image, it is nil if the Canvas was
+	// created with NewImageWithContext.
+	painter *painter
 }
 
 // New returns a new image canvas with
 // the size specified  rounded up to the
 // nearest pixel.
 func New(width, height vg.Length) *Canvas {
-	w := width / vg.Inch * dpi
-	h := height / vg.Inch * dpi
+	return NewWithDPI(width, height, dpi)
+}
+
+// NewWithDPI returns a new image canvas with
+// the size specified rounded up to the nearest
+// pixel at the given number of dots per inch.
+func NewWithDPI(width, height vg.Length, dpi int) *Canvas {
+	w := float64(width/vg.Inch) * float64(dpi)
+	h := float64(height/vg.Inch) * float64(dpi)
 	img := image.NewRGBA(image.Rect(0, 0, int(w), int(h)))
 
-	return NewImage(img)
+	return newImage(img, dpi)
 }
 
 // NewImage returns a new image canvas
@@ -53,12 +66,27 @@ func New(width, height vg.Length) *Canvas {
 // minimum point of the given image
 // should probably be 0,0.
 func NewImage(img draw.Image) *Canvas {
+	return newImage(img, dpi)
+}
+
+// newImage returns a new image canvas that draws
+// to the given image at the given dots per inch.
+func newImage(img draw.Image, dpi int) *Canvas {
 	h := float64(img.Bounds().Max.Y - img.Bounds().Min.Y)
-	gc := draw2d.NewGraphicContext(img)
+	var p *painter
+	var gc draw2d.GraphicContext
+	if rgba, ok := img.(*image.RGBA); ok {
+		p = &painter{RGBAPainter: raster.NewRGBAPainter(rgba)}
+		gc = draw2d.NewGraphicContextWithPainter(img, p)
+	} else {
+		gc = draw2d.NewGraphicContext(img)
+	}
 	gc.SetDPI(dpi)
 	gc.Scale(1, -1)
 	gc.Translate(0, -h)
-	return NewImageWithContext(img, gc)
+	c := newImageWithContext(img, gc, dpi)
+	c.painter = p
+	return c
 }
 
 // NewImageWithContext returns a new image canvas
@@ -66,20 +94,78 @@ func NewImage(img draw.Image) *Canvas {
 // The minimum point of the given image
 // should probably be 0,0.
 func NewImageWithContext(img draw.Image, gc draw2d.GraphicContext) *Canvas {
+	return newImageWithContext(img, gc, dpi)
+}
+
+func newImageWithContext(img draw.Image, gc draw2d.GraphicContext, dpi int) *Canvas {
 	w := float64(img.Bounds().Max.X - img.Bounds().Min.X)
 	h := float64(img.Bounds().Max.Y - img.Bounds().Min.Y)
 	draw.Draw(img, img.Bounds(), image.White, image.ZP, draw.Src)
 	c := &Canvas{
 		gc:    gc,
 		img:   img,
-		w:     vg.Length(w/dpi) * vg.Inch,
-		h:     vg.Length(h/dpi) * vg.Inch,
+		w:     vg.Length(w/float64(dpi)) * vg.Inch,
+		h:     vg.Length(h/float64(dpi)) * vg.Inch,
 		color: []color.Color{color.Black},
 	}
 	vg.Initialize(c)
 	return c
 }
 
+// SetAntialias sets whether lines, fills and text
+// are antialiased.  Canvases are antialiased by default.
+//
+// Without antialiasing each pixel is either fully
+// painted or left untouched, giving hard, jagged
+// edges that are suitable for pixel-art style output.
+// The resulting images contain fewer distinct colors
+// and so they typically compress to smaller PNG files,
+// however rasterization itself is not noticeably
+// faster as pixel coverage is still computed.
+//
+// SetAntialias has no effect on Canvases created by
+// NewImageWithContext or by NewImage with an image
+// that is not an *image.RGBA.
+func (c *Canvas) SetAntialias(aa bool) {
+	if c.painter == nil {
+		return
+	}
+	c.painter.aliased = !aa
+}
+
+// painter is a raster.RGBAPainter that can
+// optionally disable antialiasing.
+type painter struct {
+	*raster.RGBAPainter
+
+	// aliased is true if antialiasing is disabled.
+	aliased bool
+}
+
+// Paint satisfies the raster.Painter interface.
+// If antialiasing is disabled then the alpha of each
+// span is rounded to either fully opaque or fully
+// transparent before painting.
+func (p *painter) Paint(ss []raster.Span, done bool) {
+	if p.aliased {
+		const m = 1<<32 - 1
+		for i := range ss {
+			if ss[i].A >= m/2 {
+				ss[i].A = m
+			} else {
+				ss[i].A = 0
+			}
+		}
+	}
+	p.RGBAPainter.Paint(ss, done)
+}
+
+// Image returns the image to which the
+// Canvas draws.
+func (c *Canvas) Image() image.Image {
+	return c.img
+}
+
 func (c *Canvas) Size() (w, h vg.Length) {
 	return c.w, c.h
 }
@@ -2,6 +2,7 @@ package vgimg_test
 
 import (
 	"bytes"
+	"image/color"
 	"io/ioutil"
 	"log"
 	"os"
@@ -48,3 +49,86 @@ func TestIssue179(t *testing.T) {
 		t.Error("Image mismatch")
 	}
 }
+
+// strokeDiagonal strokes a thick black diagonal line
+// across a small canvas and returns the canvas.
+func strokeDiagonal(aa bool) *vgimg.Canvas {
+	c := vgimg.NewWithDPI(20, 20, 72)
+	c.SetAntialias(aa)
+	c.SetColor(color.Black)
+	c.SetLineWidth(3)
+	var p vg.Path
+	p.Move(2, 3)
+	p.Line(18, 15)
+	c.Stroke(p)
+	return c
+}
+
+func TestSetAntialias(t *testing.T) {
+	for _, aa := range []bool{true, false} {
+		img := strokeDiagonal(aa).Image()
+		var black, white, partial int
+		b := img.Bounds()
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				switch color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y {
+				case 0:
+					black++
+				case 0xff:
+					white++
+				default:
+					partial++
+				}
+			}
+		}
+		if black == 0 || white == 0 {
+			t.Errorf("unexpected pixels with antialias=%t: got %d black and %d white", aa, black, white)
+		}
+		if aa && partial == 0 {
+			t.Error("no partially painted pixels with antialiasing")
+		}
+		if !aa && partial != 0 {
+			t.Errorf("unexpected partially painted pixels without antialiasing: got %d", partial)
+		}
+	}
+}
+
+func TestAlphaBlend(t *testing.T) {
+	c := vgimg.NewWithDPI(20, 20, 72)
+	c.SetLineWidth(6)
+	c.SetColor(color.NRGBA{R: 0xff, A: 0x80})
+	var p vg.Path
+	p.Move(0, 10)
+	p.Line(20, 10)
+	c.Stroke(p)
+	c.SetColor(color.NRGBA{B: 0xff, A: 0x80})
+	p = vg.Path{}
+	p.Move(10, 0)
+	p.Line(10, 20)
+	c.Stroke(p)
+
+	img := c.Image()
+	for _, test := range []struct {
+		x, y    int
+		r, g, b uint8
+	}{
+		// Red over white.
+		{x: 3, y: 10, r: 0xff, g: 0x7f, b: 0x7f},
+		// Blue over white.
+		{x: 10, y: 3, r: 0x7f, g: 0x7f, b: 0xff},
+		// Blue over red over white.
+		{x: 10, y: 10, r: 0x7f, g: 0x3f, b: 0xbf},
+	} {
+		got := color.NRGBAModel.Convert(img.At(test.x, test.y)).(color.NRGBA)
+		if got.A != 0xff || !near(got.R, test.r) || !near(got.G, test.g) || !near(got.B, test.b) {
+			t.Errorf("unexpected color at (%d,%d): got:%v want:%v", test.x, test.y, got,
+				color.NRGBA{R: test.r, G: test.g, B: test.b, A: 0xff})
+		}
+	}
+}
+
+// near returns whether two color components differ
+// by no more than rounding.
+func near(a, b uint8) bool {
+	return a-b <= 2 || b-a <= 2
+}
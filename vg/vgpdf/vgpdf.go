@@ -3,7 +3,7 @@
 // license that can be found in the LICENSE file.
 
 // Package vgpdf implements the vg.Canvas interface
-// using gopdf (bitbucket.org/zombiezen/gopdf/pdf).
+// using gofpdf (github.com/jung-kurt/gofpdf).
 package vgpdf
 
 import (
@@ -12,29 +12,37 @@ import (
 	"image/color"
 	"io"
 	"math"
+	"strings"
 
-	"bitbucket.org/zombiezen/gopdf/pdf"
 	"github.com/gonum/plot/vg"
+	"github.com/jung-kurt/gofpdf"
 )
 
 // Canvas implements the vg.Canvas interface,
 // drawing to a PDF.
 type Canvas struct {
-	doc         *pdf.Document
+	doc         *gofpdf.Fpdf
 	w, h        vg.Length
-	page        *pdf.Canvas
 	lineVisible bool
 }
 
 // New creates a new PDF Canvas.
 func New(w, h vg.Length) *Canvas {
 	c := &Canvas{
-		doc:         pdf.New(),
+		doc: gofpdf.NewCustom(&gofpdf.InitType{
+			UnitStr: "pt",
+			Size:    gofpdf.SizeType{Wd: w.Points(), Ht: h.Points()},
+		}),
 		w:           w,
 		h:           h,
 		lineVisible: true,
 	}
-	c.page = c.doc.NewPage(unit(w), unit(h))
+	c.doc.SetMargins(0, 0, 0)
+	c.doc.SetAutoPageBreak(false, 0)
+	c.doc.AddPage()
+	// gofpdf only applies transformations
+	// within a transformation context.
+	c.doc.TransformBegin()
 	vg.Initialize(c)
 	return c
 }
@@ -44,83 +52,87 @@ func (c *Canvas) Size() (w, h vg.Length) {
 }
 
 func (c *Canvas) SetLineWidth(w vg.Length) {
-	c.page.SetLineWidth(unit(w))
+	c.doc.SetLineWidth(w.Points())
 	c.lineVisible = w > 0
 }
 
 func (c *Canvas) SetLineDash(dashes []vg.Length, offs vg.Length) {
-	ds := make([]pdf.Unit, len(dashes))
+	ds := make([]float64, len(dashes))
 	for i, d := range dashes {
-		ds[i] = unit(d)
+		ds[i] = d.Points()
 	}
-	c.page.SetLineDash(unit(offs), ds)
+	c.doc.SetDashPattern(ds, offs.Points())
 }
 
 func (c *Canvas) SetColor(clr color.Color) {
-	c.page.SetStrokeColor(pdfColor(clr))
-	c.page.SetColor(pdfColor(clr))
+	r, g, b, a := pdfColor(clr)
+	c.doc.SetDrawColor(r, g, b)
+	c.doc.SetFillColor(r, g, b)
+	c.doc.SetTextColor(r, g, b)
+	c.doc.SetAlpha(a, "Normal")
 }
 
 func (c *Canvas) Rotate(r float64) {
-	c.page.Rotate(float32(r))
+	sin, cos := math.Sincos(r)
+	c.doc.Transform(gofpdf.TransformMatrix{A: cos, B: sin, C: -sin, D: cos})
 }
 
 func (c *Canvas) Translate(x vg.Length, y vg.Length) {
-	c.page.Translate(unit(x), unit(y))
+	c.doc.Transform(gofpdf.TransformMatrix{A: 1, D: 1, E: x.Points(), F: y.Points()})
 }
 
 func (c *Canvas) Scale(x float64, y float64) {
-	c.page.Scale(float32(x), float32(y))
+	c.doc.Transform(gofpdf.TransformMatrix{A: x, D: y})
 }
 
 func (c *Canvas) Push() {
-	c.page.Push()
+	c.doc.TransformBegin()
 }
 
 func (c *Canvas) Pop() {
-	c.page.Pop()
+	c.doc.TransformEnd()
 }
 
 func (c *Canvas) Stroke(p vg.Path) {
 	if c.lineVisible {
-		c.page.Stroke(pdfPath(c, p))
+		pdfPath(c, p)
+		c.doc.DrawPath("S")
 	}
 }
 
 func (c *Canvas) Fill(p vg.Path) {
-	c.page.Fill(pdfPath(c, p))
+	pdfPath(c, p)
+	c.doc.DrawPath("f")
 }
 
 func (c *Canvas) FillString(fnt vg.Font, x, y vg.Length, str string) {
-	t := new(pdf.Text)
-	t.SetFont(fnt.Name(), unit(fnt.Size))
-	t.NextLineOffset(unit(x), unit(y))
-	t.Text(str)
-	c.page.DrawText(t)
+	family, style := pdfFont(fnt.Name())
+	c.doc.SetFont(family, style, fnt.Size.Points())
+	px, py := c.pdfPoint(x, y)
+	c.doc.Text(px, py, str)
 }
 
 func (*Canvas) DPI() float64 {
-	return float64(pdf.Inch)
+	return 72
 }
 
-// pdfPath returns a pdf.Path from a vg.Path.
-func pdfPath(c *Canvas, path vg.Path) *pdf.Path {
-	p := new(pdf.Path)
+// pdfPath adds a vg.Path to the current path
+// of the PDF.
+func pdfPath(c *Canvas, path vg.Path) {
 	for _, comp := range path {
 		switch comp.Type {
 		case vg.MoveComp:
-			p.Move(pdfPoint(comp.X, comp.Y))
+			c.doc.MoveTo(c.pdfPoint(comp.X, comp.Y))
 		case vg.LineComp:
-			p.Line(pdfPoint(comp.X, comp.Y))
+			c.doc.LineTo(c.pdfPoint(comp.X, comp.Y))
 		case vg.ArcComp:
-			arc(p, comp)
+			arc(c, comp)
 		case vg.CloseComp:
-			p.Close()
+			c.doc.ClosePath()
 		default:
 			panic(fmt.Sprintf("Unknown path component type: %d\n", comp.Type))
 		}
 	}
-	return p
 }
 
 // Approximate a circular arc using multiple
@@ -128,10 +140,10 @@ func pdfPath(c *Canvas, path vg.Path) *pdf.Path {
 //
 // This is from:
 // 	http://hansmuller-flex.blogspot.com/2011/04/approximating-circular-arc-with-cubic.html
-func arc(p *pdf.Path, comp vg.PathComp) {
+func arc(c *Canvas, comp vg.PathComp) {
 	x0 := comp.X + comp.Radius*vg.Length(math.Cos(comp.Start))
 	y0 := comp.Y + comp.Radius*vg.Length(math.Sin(comp.Start))
-	p.Line(pdfPoint(x0, y0))
+	c.doc.LineTo(c.pdfPoint(x0, y0))
 
 	a1 := comp.Start
 	end := a1 + comp.Angle
@@ -148,7 +160,7 @@ func arc(p *pdf.Path, comp vg.PathComp) {
 
 	for left > epsilon {
 		a2 := a1 + sign*math.Min(math.Pi/2, left)
-		partialArc(p, comp.X, comp.Y, comp.Radius, a1, a2)
+		partialArc(c, comp.X, comp.Y, comp.Radius, a1, a2)
 		left -= math.Abs(a2 - a1)
 		a1 = a2
 	}
@@ -156,7 +168,7 @@ func arc(p *pdf.Path, comp vg.PathComp) {
 
 // Approximate a circular arc of fewer than π/2
 // radians with cubic Bézier curve.
-func partialArc(p *pdf.Path, x, y, r vg.Length, a1, a2 float64) {
+func partialArc(c *Canvas, x, y, r vg.Length, a1, a2 float64) {
 	a := (a2 - a1) / 2
 	x4 := r * vg.Length(math.Cos(a))
 	y4 := r * vg.Length(math.Sin(a))
@@ -180,26 +192,47 @@ func partialArc(p *pdf.Path, x, y, r vg.Length, a1, a2 float64) {
 	y3r := x3*sinar + y3*cosar + y
 	x4 = r*vg.Length(math.Cos(a2)) + x
 	y4 = r*vg.Length(math.Sin(a2)) + y
-	p.Curve(pdfPoint(x2r, y2r), pdfPoint(x3r, y3r), pdfPoint(x4, y4))
+	x2p, y2p := c.pdfPoint(x2r, y2r)
+	x3p, y3p := c.pdfPoint(x3r, y3r)
+	x4p, y4p := c.pdfPoint(x4, y4)
+	c.doc.CurveBezierCubicTo(x2p, y2p, x3p, y3p, x4p, y4p)
 }
 
-func pdfPoint(x, y vg.Length) pdf.Point {
-	return pdf.Point{X: unit(x), Y: unit(y)}
+// pdfPoint returns the gofpdf coordinates of a point.
+// The origin of gofpdf is the top left corner of the page,
+// with y increasing downwards, so that the point is
+// reflected about the middle of the page height.
+func (c *Canvas) pdfPoint(x, y vg.Length) (float64, float64) {
+	return x.Points(), (c.h - y).Points()
 }
 
-func pdfColor(clr color.Color) (float32, float32, float32) {
+// pdfColor returns the 8-bit, non-alpha-premultiplied
+// red, green and blue components of a color,
+// and its alpha in the range [0, 1].
+func pdfColor(clr color.Color) (r, g, b int, a float64) {
 	if clr == nil {
 		clr = color.Black
 	}
-	r, g, b, _ := clr.RGBA()
-	return float32(r) / math.MaxUint16,
-		float32(g) / math.MaxUint16,
-		float32(b) / math.MaxUint16
+	c := color.NRGBAModel.Convert(clr).(color.NRGBA)
+	return int(c.R), int(c.G), int(c.B), float64(c.A) / math.MaxUint8
 }
 
-// unit returns a pdf.Unit, converted from a vg.Length.
-func unit(l vg.Length) pdf.Unit {
-	return pdf.Unit(l.Points()) * pdf.Pt
+// pdfFont returns the gofpdf family and style
+// of the standard PDF font with the given name.
+func pdfFont(name string) (family, style string) {
+	family = name
+	if i := strings.Index(name, "-"); i >= 0 {
+		family = name[:i]
+		switch name[i+1:] {
+		case "Bold":
+			style = "B"
+		case "Italic", "Oblique":
+			style = "I"
+		case "BoldItalic", "BoldOblique":
+			style = "BI"
+		}
+	}
+	return family, style
 }
 
 // WriterCounter implements the io.Writer interface, and counts
@@ -219,10 +252,10 @@ func (w *writerCounter) Write(p []byte) (int, error) {
 // After calling Write, the canvas is closed
 // and may no longer be used for drawing.
 func (c *Canvas) WriteTo(w io.Writer) (int64, error) {
-	c.page.Close()
+	c.doc.TransformEnd()
 	wc := writerCounter{Writer: w}
 	b := bufio.NewWriter(&wc)
-	if err := c.doc.Encode(b); err != nil {
+	if err := c.doc.Output(b); err != nil {
 		return wc.n, err
 	}
 	err := b.Flush()
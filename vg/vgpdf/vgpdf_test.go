@@ -0,0 +1,83 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vgpdf
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"image/color"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gonum/plot/vg"
+)
+
+func TestAlphaBlend(t *testing.T) {
+	c := New(20, 20)
+	c.SetLineWidth(6)
+	c.SetColor(color.NRGBA{R: 0xff, A: 0x80})
+	var p vg.Path
+	p.Move(0, 10)
+	p.Line(20, 10)
+	c.Stroke(p)
+	c.SetColor(color.NRGBA{B: 0xff, A: 0x80})
+	p = vg.Path{}
+	p.Move(10, 0)
+	p.Line(10, 20)
+	c.Stroke(p)
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("failed to write PDF: %v", err)
+	}
+	out := buf.String()
+
+	// alpha is the stroke alpha of each
+	// named graphics state of the page.
+	alpha := make(map[string]string)
+	for _, res := range regexp.MustCompile(`/(GS\d+) (\d+) 0 R`).FindAllStringSubmatch(out, -1) {
+		obj := regexp.MustCompile(`(?m)^` + res[2] + ` 0 obj\n<</Type /ExtGState .*/CA ([0-9.]+)`).FindStringSubmatch(out)
+		if obj == nil {
+			t.Fatalf("missing graphics state %s", res[1])
+		}
+		alpha[res[1]] = obj[1]
+	}
+
+	stream := regexp.MustCompile(`(?s)stream\n(.*?)\nendstream`).FindStringSubmatch(out)
+	if stream == nil {
+		t.Fatal("missing page content")
+	}
+	r, err := zlib.NewReader(strings.NewReader(stream[1]))
+	if err != nil {
+		t.Fatalf("failed to decompress page content: %v", err)
+	}
+	type stroke struct{ color, alpha string }
+	var got []stroke
+	var cur stroke
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		switch op := sc.Text(); {
+		case strings.HasSuffix(op, " RG"):
+			cur.color = strings.TrimSuffix(op, " RG")
+		case strings.HasSuffix(op, " gs"):
+			cur.alpha = alpha[strings.TrimPrefix(strings.TrimSuffix(op, " gs"), "/")]
+		case op == "S":
+			got = append(got, cur)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatalf("failed to read page content: %v", err)
+	}
+	want := []stroke{
+		{color: "1.000 0.000 0.000", alpha: "0.502"},
+		{color: "0.000 0.000 1.000", alpha: "0.502"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected strokes: got:%v want:%v", got, want)
+	}
+}
@@ -34,6 +34,9 @@ type Canvas struct {
 	buf  *bytes.Buffer
 	ht   float64
 	stk  []context
+
+	// clips is the number of clip paths defined.
+	clips int
 }
 
 type context struct {
@@ -144,6 +147,19 @@ func (c *Canvas) Fill(path vg.Path) {
 			elm("fill-opacity", "1", opacityString(c.cur().color))))
 }
 
+// Clip implements the vg.Clipper interface.  The clip
+// path is defined where it is used and applies to a group
+// that is ended by the Pop of the current context.
+func (c *Canvas) Clip(path vg.Path) {
+	c.clips++
+	id := fmt.Sprintf("clip%d", c.clips)
+	c.svg.ClipPath(fmt.Sprintf(`id="%s"`, id))
+	c.svg.Path(c.pathData(path))
+	c.svg.ClipEnd()
+	c.svg.Group(fmt.Sprintf(`clip-path="url(#%s)"`, id))
+	c.cur().gEnds++
+}
+
 func (c *Canvas) pathData(path vg.Path) string {
 	buf := new(bytes.Buffer)
 	var x, y float64
@@ -251,7 +267,8 @@ func (c *Canvas) FillString(font vg.Font, x, y vg.Length, str string) {
 	}
 	sty := style(fontStr,
 		elm("font-size", "medium", "%.*gpt", pr, font.Size.Points()),
-		elm("fill", "#000000", colorString(c.cur().color)))
+		elm("fill", "#000000", colorString(c.cur().color)),
+		elm("fill-opacity", "1", opacityString(c.cur().color)))
 	if sty != "" {
 		sty = "\n\t" + sty
 	}
@@ -374,6 +391,11 @@ func colorString(clr color.Color) string {
 		clr = color.Black
 	}
 	r, g, b, _a := clr.RGBA()
+	if _a == 0 {
+		// A fully transparent color has no
+		// meaningful RGB components.
+		return "#000000"
+	}
 	a := 255.0 / float64(_a)
 	return fmt.Sprintf("#%02X%02X%02X", int(float64(r)*a),
 		int(float64(g)*a), int(float64(b)*a))
@@ -0,0 +1,166 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vgsvg
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/color"
+	"io"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gonum/plot/vg"
+)
+
+func TestAlpha(t *testing.T) {
+	c := New(vg.Inch, vg.Inch)
+	c.SetColor(color.NRGBA{R: 255, A: 128})
+	var p vg.Path
+	p.Move(0, vg.Inch/2)
+	p.Line(vg.Inch, vg.Inch/2)
+	c.Stroke(p)
+	p = vg.Path{}
+	p.Move(vg.Inch/2, 0)
+	p.Line(vg.Inch/2, vg.Inch)
+	c.Stroke(p)
+	c.Fill(p)
+	fnt, err := vg.MakeFont("Helvetica", 12)
+	if err != nil {
+		t.Fatalf("failed to make font: %v", err)
+	}
+	c.FillString(fnt, 0, 0, "text")
+	c.SetColor(color.Transparent)
+	c.Fill(p)
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("failed to write SVG: %v", err)
+	}
+	out := buf.String()
+	for _, test := range []struct {
+		want string
+		n    int
+	}{
+		{want: "stroke:#FF0000;stroke-opacity:0.50196", n: 2},
+		{want: "fill:#FF0000;fill-opacity:0.50196", n: 2},
+		{want: `style="fill-opacity:0"`, n: 1},
+	} {
+		if got := strings.Count(out, test.want); got != test.n {
+			t.Errorf("unexpected number of %q: got:%d want:%d", test.want, got, test.n)
+		}
+	}
+}
+
+func TestAlphaBlend(t *testing.T) {
+	c := New(20, 20)
+	c.SetLineWidth(6)
+	c.SetColor(color.NRGBA{R: 0xff, A: 0x80})
+	var p vg.Path
+	p.Move(0, 10)
+	p.Line(20, 10)
+	c.Stroke(p)
+	c.SetColor(color.NRGBA{B: 0xff, A: 0x80})
+	p = vg.Path{}
+	p.Move(10, 0)
+	p.Line(10, 20)
+	c.Stroke(p)
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("failed to write SVG: %v", err)
+	}
+	var got []string
+	for _, m := range regexp.MustCompile(`stroke:(#[0-9A-F]{6});stroke-opacity:([0-9.]+)`).FindAllStringSubmatch(buf.String(), -1) {
+		got = append(got, m[1]+" "+m[2])
+	}
+	// The later, blue stroke is composited
+	// over the earlier, red one.
+	want := []string{"#FF0000 0.50196", "#0000FF 0.50196"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected strokes: got:%q want:%q", got, want)
+	}
+}
+
+func TestClip(t *testing.T) {
+	c := New(vg.Inch, vg.Inch)
+	var circle vg.Path
+	circle.Move(vg.Inch, vg.Inch/2)
+	circle.Arc(vg.Inch/2, vg.Inch/2, vg.Inch/2, 0, 2*math.Pi)
+	circle.Close()
+	var square vg.Path
+	square.Move(0, 0)
+	square.Line(vg.Inch, 0)
+	square.Line(vg.Inch, vg.Inch)
+	square.Line(0, vg.Inch)
+	square.Close()
+
+	c.Push()
+	c.Clip(circle)
+	c.Push()
+	c.Translate(1, 1)
+	c.Clip(square)
+	c.Fill(square)
+	c.Pop()
+	c.Pop()
+	c.Fill(square)
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("failed to write SVG: %v", err)
+	}
+	// clipped is the number of clipped groups
+	// enclosing each of the filled paths.
+	var clipped []int
+	var groups []bool
+	var inClipPath bool
+	dec := xml.NewDecoder(&buf)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("invalid SVG: %v", err)
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			switch tok.Name.Local {
+			case "clipPath":
+				inClipPath = true
+			case "g":
+				clip := false
+				for _, a := range tok.Attr {
+					clip = clip || a.Name.Local == "clip-path"
+				}
+				groups = append(groups, clip)
+			case "path":
+				if inClipPath {
+					continue
+				}
+				n := 0
+				for _, clip := range groups {
+					if clip {
+						n++
+					}
+				}
+				clipped = append(clipped, n)
+			}
+		case xml.EndElement:
+			switch tok.Name.Local {
+			case "clipPath":
+				inClipPath = false
+			case "g":
+				groups = groups[:len(groups)-1]
+			}
+		}
+	}
+	if want := []int{2, 0}; !reflect.DeepEqual(clipped, want) {
+		t.Errorf("unexpected clipping of filled paths: got:%v want:%v", clipped, want)
+	}
+}